@@ -0,0 +1,85 @@
+package frangipani
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	l4 "github.com/go-eden/slf4go"
+)
+
+// The name of logger for deprecation warnings of properties
+const LOGGER_NAME_DEPRECATION = "fgapp.deprecation"
+
+var deprecationLogger = l4.NewLogger(LOGGER_NAME_DEPRECATION)
+
+// The deprecated names which have been warned
+var warnedAliases sync.Map
+
+// Registers the deprecated names(aliases) of a property, which are used if the property is not existing.
+//
+//   fg.RegisterAlias("db.host", "db.hostname", "database.host")
+//
+//   // Gives the value of "db.hostname" if "db.host" is not existing
+//   env.GetProperty("db.host")
+//
+// The aliases are registered as deprecated metadata(see "RegisterMetadata()") having the property as replacement,
+// a warning is logged(once for every alias) while the value of an alias is read("ContainsProperty()" doesn't warn).
+func RegisterAlias(name string, aliases ...string) {
+	for _, alias := range aliases {
+		metadata, _ := GetMetadata(alias)
+
+		metadata.Name = alias
+		metadata.Deprecated = true
+		metadata.Replacement = name
+		RegisterMetadata(metadata)
+	}
+}
+
+// Gets the deprecated names(aliases) of the property(sorted), see "RegisterAlias()".
+func GetAliases(name string) []string {
+	return append(make([]string, 0), aliasesOf(name)...)
+}
+
+// The index of relaxed names(of replacements) to their deprecated names(sorted),
+// which is rebuilt by "RegisterMetadata()" and read without locking.
+var aliasIndex atomic.Value
+
+func init() {
+	aliasIndex.Store(make(map[string][]string))
+}
+
+// Gets the aliases of property from the index(the returned slice must not be modified)
+func aliasesOf(name string) []string {
+	index := aliasIndex.Load().(map[string][]string)
+	if len(index) == 0 {
+		return nil
+	}
+
+	return index[relaxedName(name)]
+}
+
+// Rebuilds the index of aliases, the lock of metadata must be held by caller.
+func rebuildAliasIndex() {
+	index := make(map[string][]string)
+	for _, metadata := range registeredMetadata {
+		if metadata.Deprecated && metadata.Replacement != "" {
+			replacement := relaxedName(metadata.Replacement)
+			index[replacement] = append(index[replacement], metadata.Name)
+		}
+	}
+
+	for _, aliases := range index {
+		sort.Strings(aliases)
+	}
+
+	aliasIndex.Store(index)
+}
+
+func warnDeprecatedAlias(alias string, name string) {
+	if _, warned := warnedAliases.LoadOrStore(relaxedName(alias), true); warned {
+		return
+	}
+
+	deprecationLogger.Warnf("Property[%s] is deprecated, use [%s] instead", alias, name)
+}
@@ -0,0 +1,77 @@
+package frangipani
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Aliases of properties", func() {
+	var oldMetadata map[string]PropertyMetadata
+
+	BeforeEach(func() {
+		oldMetadata = registeredMetadata
+		registeredMetadata = make(map[string]PropertyMetadata)
+
+		RegisterMetadata(PropertyMetadata{ Name: "cache.ttl", Type: "duration", Description: "TTL of cache" })
+		RegisterAlias("cache.time-to-live", "cache.ttl", "cache.expiry")
+	})
+	AfterEach(func() {
+		registeredMetadata = oldMetadata
+		rebuildAliasIndex()
+	})
+
+	testedEnv := EnvBuilder.NewByMap(map[string]interface{} {
+		"cache.ttl": "10s",
+		"cache.expiry": "20s",
+		"db.host": "10.1.1.1",
+		"cache.limit": 30,
+	})
+
+	DescribeTable("GetProperty",
+		func(name string, expected string) {
+			Expect(testedEnv.GetProperty(name)).To(Equal(expected))
+		},
+		Entry("By alias", "cache.time-to-live", "20s"),
+		Entry("By alias(relaxed name)", "cache.timeToLive", "20s"),
+		Entry("Alias itself", "cache.ttl", "10s"),
+		Entry("Not aliased", "db.host", "10.1.1.1"),
+	)
+
+	DescribeTable("ContainsProperty",
+		func(name string, expected bool) {
+			Expect(testedEnv.ContainsProperty(name)).To(Equal(expected))
+		},
+		Entry("By alias", "cache.time-to-live", true),
+		Entry("Not existing", "cache.size", false),
+	)
+
+	It("Warns only if the value of alias is read", func() {
+		RegisterAlias("cache.max-size", "cache.limit")
+		warnedAliases.Delete(relaxedName("cache.limit"))
+
+		isWarned := func() bool {
+			_, warned := warnedAliases.Load(relaxedName("cache.limit"))
+			return warned
+		}
+
+		Expect(testedEnv.ContainsProperty("cache.max-size")).To(BeTrue())
+		Expect(isWarned()).To(BeFalse())
+
+		Expect(testedEnv.Typed().GetInt("cache.max-size")).To(Equal(30))
+		Expect(isWarned()).To(BeTrue())
+	})
+
+	It("GetAliases", func() {
+		Expect(GetAliases("cache.timeToLive")).To(Equal([]string{ "cache.expiry", "cache.ttl" }))
+		Expect(GetAliases("db.host")).To(BeEmpty())
+	})
+
+	It("Metadata of aliases", func() {
+		metadata, _ := GetMetadata("cache.ttl")
+
+		Expect(metadata).To(Equal(PropertyMetadata {
+			Name: "cache.ttl", Type: "duration", Description: "TTL of cache",
+			Deprecated: true, Replacement: "cache.time-to-live",
+		}))
+	})
+})
@@ -0,0 +1,443 @@
+/*
+Binding of properties
+
+You can bind properties with a prefix to fields of a struct by "BindProperties()".
+
+  type DbConfig struct {
+    Host string `fg:"host" validate:"required"`
+    Port int `fg:"port:5432"`
+    Timeout time.Duration `fg:"timeout:10s"`
+    Pool struct {
+      MaxSize int `fg:"max-size:8" validate:"min=1"`
+    } `fg:"pool"`
+  }
+
+  var config DbConfig
+  err := fg.BindProperties(env, "db.primary", &config)
+
+The tag "fg" is formatted as "<name>[:<default value>]", "fg:"-"" means the field is skipped.
+If there is no "fg" tag, the name of "mapstructure" tag(or the name of field) is used,
+and "mapstructure:",squash"" makes the field sharing the prefix of its struct.
+
+The validation is performed by "go-playground/validator"(by tag "validate"),
+every error of conversion or validation is reported by "*BindingError".
+
+See: https://github.com/go-playground/validator
+*/
+package frangipani
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/spf13/cast"
+)
+
+// Name of tag used to bind property to field
+const TAG_PROPERTY = "fg"
+
+// Name of tag used to bind property to field if there is no "fg" tag, which is compatible with "mitchellh/mapstructure"(used by viper)
+const TAG_MAPSTRUCTURE = "mapstructure"
+
+// The error of binding properties to a struct, which contains errors of all failed fields.
+type BindingError struct {
+	// Prefix of properties
+	Prefix string
+	// Errors of failed fields
+	FieldErrors []*FieldBindingError
+}
+func (self *BindingError) Error() string {
+	messages := make([]string, 0, len(self.FieldErrors) + 1)
+	messages = append(messages, fmt.Sprintf(
+		"Binding properties of \"%s\" has [%d] error(s):",
+		self.Prefix, len(self.FieldErrors),
+	))
+
+	for _, fieldErr := range self.FieldErrors {
+		messages = append(messages, "\t" + fieldErr.Error())
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+// The error of binding a property to a field of struct.
+type FieldBindingError struct {
+	// Name of property
+	Property string
+	// Path of field, e.g., "DbConfig.Pool.MaxSize"
+	Field string
+	// The cause
+	Err error
+}
+func (self *FieldBindingError) Error() string {
+	return fmt.Sprintf("Property[%s](%s): %v", self.Property, self.Field, self.Err)
+}
+func (self *FieldBindingError) Unwrap() error {
+	return self.Err
+}
+
+var structValidator = newStructValidator()
+
+func newStructValidator() *validator.Validate {
+	newValidator := validator.New()
+
+	newValidator.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := parsePropertyTag(field)
+		return name
+	})
+
+	return newValidator
+}
+
+// Binds properties(having the prefix) to fields of a struct(must be a pointer).
+//
+// The resolver must be able to list its properties(see "PropertiesLister"),
+// the error would be "*BindingError" if any of fields is failed on conversion or validation.
+//
+// See "Binding of properties" in GoDoc.
+func BindProperties(resolver PropertyResolver, prefix string, target interface{}) error {
+	mapBasedResolver, ok := asMapBasedResolver(resolver)
+	if !ok {
+		return fmt.Errorf("Resolver[%T] cannot list its properties(see \"PropertiesLister\")", resolver)
+	}
+
+	return bindProperties(mapBasedResolver, prefix, target)
+}
+func bindProperties(props mapBasedPropertyResolver, prefix string, target interface{}) error {
+	targetValue := reflect.ValueOf(target)
+	if targetValue.Kind() != reflect.Ptr || targetValue.IsNil() ||
+		targetValue.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("Target of binding must be a non-nil pointer to struct: %T", target)
+	}
+
+	binder := &propertiesBinder{ resolver: props }
+	binder.bindStruct(prefix, targetValue.Type().Elem().Name(), targetValue.Elem())
+	binder.validate(prefix, target)
+
+	if len(binder.errors) > 0 {
+		return &BindingError{ Prefix: prefix, FieldErrors: binder.errors }
+	}
+
+	return nil
+}
+
+// Converts a map to struct by binding.
+func convertStruct(value interface{}, targetType reflect.Type) (reflect.Value, error) {
+	sourceMap, err := cast.ToStringMapE(value)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	structValue := reflect.New(targetType).Elem()
+
+	binder := &propertiesBinder{ resolver: newMapBasedPropertyResolver(sourceMap), resolved: true }
+	binder.bindStruct("", targetType.Name(), structValue)
+	if len(binder.errors) > 0 {
+		return reflect.Value{}, &BindingError{ FieldErrors: binder.errors }
+	}
+
+	return structValue, nil
+}
+
+// Binds properties to fields of struct, the errors are collected.
+type propertiesBinder struct {
+	resolver mapBasedPropertyResolver
+	// Whether or not the placeholders in values are resolved already
+	resolved bool
+	errors []*FieldBindingError
+}
+func (self *propertiesBinder) bindStruct(prefix string, fieldPath string, structValue reflect.Value) {
+	structType := structValue.Type()
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+
+		name, defaultValue, hasDefault := parsePropertyTag(field)
+		if name == "-" {
+			continue
+		}
+
+		currentFieldPath := fmt.Sprintf("%s.%s", fieldPath, field.Name)
+
+		/**
+		 * Embedded(or squashed) struct shares the same prefix
+		 */
+		if isSquashedField(field) {
+			if field.Type.Kind() == reflect.Struct {
+				self.bindStruct(prefix, currentFieldPath, structValue.Field(i))
+			}
+			continue
+		}
+		// :~)
+
+		self.bindField(
+			joinPropertyName(prefix, name), currentFieldPath,
+			structValue.Field(i), defaultValue, hasDefault,
+		)
+	}
+}
+func (self *propertiesBinder) bindField(
+	name string, fieldPath string, fieldValue reflect.Value,
+	defaultValue string, hasDefault bool,
+) {
+	fieldType := fieldValue.Type()
+
+	/**
+	 * Nested struct is bound by properties with the name as prefix
+	 */
+	switch {
+	case isBindingStruct(fieldType):
+		self.bindStruct(name, fieldPath, fieldValue)
+		return
+	case fieldType.Kind() == reflect.Ptr && isBindingStruct(fieldType.Elem()):
+		if !self.containsPrefix(name) {
+			return
+		}
+
+		if fieldValue.IsNil() {
+			fieldValue.Set(reflect.New(fieldType.Elem()))
+		}
+		self.bindStruct(name, fieldPath, fieldValue.Elem())
+		return
+	}
+	// :~)
+
+	value, found, err := self.getValue(name, fieldType.Kind() == reflect.Map)
+	if err != nil {
+		self.addError(name, fieldPath, err)
+		return
+	}
+
+	if !found {
+		if !hasDefault {
+			return
+		}
+
+		value = defaultValue
+	}
+
+	convertedValue, err := convertValue(value, fieldType)
+	if err != nil {
+		self.addError(name, fieldPath, err)
+		return
+	}
+
+	fieldValue.Set(convertedValue)
+}
+// Gets the value(placeholders are resolved) of property.
+//
+// For map, the value is merged from properties having the name as prefix.
+func (self *propertiesBinder) getValue(name string, asMap bool) (interface{}, bool, error) {
+	value, found := lookupProperty(self.resolver.props, name)
+	if !found {
+		value, found = lookupRelaxed(self.resolver.props, self.resolver.relaxedKeys, name)
+	}
+
+	if asMap {
+		mergedMap, merged := collectByPrefix(self.resolver.props, name)
+
+		if merged {
+			if found {
+				for k, v := range cast.ToStringMap(value) {
+					if _, ok := mergedMap[k]; !ok {
+						mergedMap[k] = v
+					}
+				}
+			}
+
+			value, found = mergedMap, true
+		}
+	}
+
+	if !found {
+		return nil, false, nil
+	}
+	if self.resolved {
+		return value, true, nil
+	}
+
+	resolvedValue, err := newPlaceholderResolver(self.resolver).resolveProperty(name, value)
+	if err != nil {
+		return nil, true, err
+	}
+
+	return resolvedValue, true, nil
+}
+// Collects properties(as nested map) having the name as prefix.
+func collectByPrefix(props map[string]interface{}, name string) (map[string]interface{}, bool) {
+	keyPrefix := strings.ToLower(name) + "."
+	result := make(map[string]interface{})
+	found := false
+
+	for key, value := range props {
+		if !strings.HasPrefix(strings.ToLower(key), keyPrefix) {
+			continue
+		}
+
+		found = true
+		currentMap := result
+		segments := strings.Split(key[len(keyPrefix):], ".")
+		for _, segment := range segments[:len(segments) - 1] {
+			nextMap, ok := currentMap[segment].(map[string]interface{})
+			if !ok {
+				nextMap = make(map[string]interface{})
+				currentMap[segment] = nextMap
+			}
+			currentMap = nextMap
+		}
+		currentMap[segments[len(segments) - 1]] = value
+	}
+
+	return result, found
+}
+func (self *propertiesBinder) containsPrefix(name string) bool {
+	if _, found := lookupProperty(self.resolver.props, name); found {
+		return true
+	}
+
+	_, found := collectByPrefix(self.resolver.props, name)
+	return found
+}
+func (self *propertiesBinder) validate(prefix string, target interface{}) {
+	err := structValidator.Struct(target)
+	if err == nil {
+		return
+	}
+
+	validationErrors, ok := err.(validator.ValidationErrors)
+	if !ok {
+		self.addError(prefix, reflect.TypeOf(target).Elem().Name(), err)
+		return
+	}
+
+	for _, fieldErr := range validationErrors {
+		/**
+		 * The namespace is "<Type>.<name>.<name>...", which is built by names of "fg" tag
+		 */
+		propertyName := fieldErr.Namespace()
+		if dotIndex := strings.Index(propertyName, "."); dotIndex >= 0 {
+			propertyName = propertyName[dotIndex + 1:]
+		}
+		// :~)
+
+		self.addError(
+			joinPropertyName(prefix, propertyName), fieldErr.StructNamespace(),
+			newValidationError(fieldErr),
+		)
+	}
+}
+func (self *propertiesBinder) addError(name string, fieldPath string, err error) {
+	self.errors = append(self.errors, &FieldBindingError{
+		Property: name,
+		Field: fieldPath,
+		Err: err,
+	})
+}
+
+func newValidationError(fieldErr validator.FieldError) error {
+	if fieldErr.Tag() == "required" {
+		return fmt.Errorf("property is required")
+	}
+
+	if fieldErr.Param() != "" {
+		return fmt.Errorf("validation failed on \"%s=%s\"(value: %v)",
+			fieldErr.Tag(), fieldErr.Param(), fieldErr.Value())
+	}
+
+	return fmt.Errorf("validation failed on \"%s\"(value: %v)",
+		fieldErr.Tag(), fieldErr.Value())
+}
+
+// Looks up property by name, the value of map(e.g., "db" for "db.host") is looked up as well.
+//
+// If the name is not found, the lower case of name is used(viper keeps keys as lower case).
+func lookupProperty(props map[string]interface{}, name string) (interface{}, bool) {
+	if value, ok := props[name]; ok {
+		return value, true
+	}
+	if value, ok := props[strings.ToLower(name)]; ok {
+		return value, true
+	}
+
+	for dotIndex := strings.LastIndex(name, "."); dotIndex > 0; dotIndex = strings.LastIndex(name[:dotIndex], ".") {
+		parentValue, ok := props[name[:dotIndex]]
+		if !ok {
+			parentValue, ok = props[strings.ToLower(name[:dotIndex])]
+		}
+		if !ok {
+			continue
+		}
+
+		parentMap, err := cast.ToStringMapE(parentValue)
+		if err != nil {
+			return nil, false
+		}
+
+		return lookupProperty(parentMap, name[dotIndex + 1:])
+	}
+
+	return nil, false
+}
+
+// Parses the "fg" tag of field as "<name>[:<default value>]"
+//
+// If there is no "fg" tag, the name of "mapstructure" tag is used.
+func parsePropertyTag(field reflect.StructField) (name string, defaultValue string, hasDefault bool) {
+	tag := field.Tag.Get(TAG_PROPERTY)
+	if tag == "" {
+		if mapstructureName, _ := parseMapstructureTag(field); mapstructureName != "" {
+			return mapstructureName, "", false
+		}
+
+		return field.Name, "", false
+	}
+
+	separatorIndex := strings.Index(tag, PLACEHOLDER_VALUE_SEPARATOR)
+	if separatorIndex == -1 {
+		return tag, "", false
+	}
+
+	return tag[:separatorIndex], tag[separatorIndex + len(PLACEHOLDER_VALUE_SEPARATOR):], true
+}
+
+// Parses the "mapstructure" tag of field as "<name>[,<option>...]"
+func parseMapstructureTag(field reflect.StructField) (name string, options []string) {
+	tagValues := strings.Split(field.Tag.Get(TAG_MAPSTRUCTURE), ",")
+	return strings.TrimSpace(tagValues[0]), tagValues[1:]
+}
+
+// The embedded struct(without name of tag) or the field tagged by "mapstructure:",squash""
+func isSquashedField(field reflect.StructField) bool {
+	if field.Tag.Get(TAG_PROPERTY) != "" {
+		return false
+	}
+
+	name, options := parseMapstructureTag(field)
+	for _, option := range options {
+		if strings.TrimSpace(option) == "squash" {
+			return true
+		}
+	}
+
+	return field.Anonymous && name == ""
+}
+
+func joinPropertyName(prefix string, name string) string {
+	if prefix == "" {
+		return name
+	}
+
+	return fmt.Sprintf("%s.%s", prefix, name)
+}
+
+// Checks whether or not the type is a struct which should be bound by its fields.
+//
+// The structs having converters(e.g., "url.URL") are converted as values.
+func isBindingStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t != typeOfTime && !hasConverter(t)
+}
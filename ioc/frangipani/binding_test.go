@@ -0,0 +1,175 @@
+package frangipani
+
+import (
+	"errors"
+	"time"
+
+	bs "github.com/inhies/go-bytesize"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Binding of properties", func() {
+	Context("BindProperties", func() {
+		It("Binds properties(flatten)", func() {
+			testedEnv := EnvBuilder.NewByMap(map[string]interface{} {
+				"db.primary.host": "10.71.8.91",
+				"db.primary.port": "5433",
+				"db.primary.timeout": "15s",
+				"db.primary.buffer-size": "4 kb",
+				"db.primary.tags": []string{ "t1", "t2" },
+				"db.primary.ports": []interface{}{ 8801, "8802" },
+				"db.primary.options.sslmode": "disable",
+				"db.primary.options.app": "${app.name}",
+				"db.primary.pool.max-size": 12,
+				"db.primary.replica.host": "10.71.8.92",
+				"db.primary.ignored": "v1",
+				"app.name": "white-plum",
+			})
+
+			var testedConfig sampleDbConfig
+			err := BindProperties(testedEnv, "db.primary", &testedConfig)
+
+			Expect(err).To(Succeed())
+			Expect(testedConfig.Host).To(BeEquivalentTo("10.71.8.91"))
+			Expect(testedConfig.Port).To(BeEquivalentTo(5433))
+			Expect(testedConfig.User).To(BeEquivalentTo("postgres"))
+			Expect(testedConfig.Timeout).To(BeEquivalentTo(15 * time.Second))
+			Expect(testedConfig.BufferSize).To(BeEquivalentTo(4 * bs.KB))
+			Expect(testedConfig.Tags).To(ConsistOf("t1", "t2"))
+			Expect(testedConfig.Ports).To(ConsistOf(uint16(8801), uint16(8802)))
+			Expect(testedConfig.Options).To(And(
+				HaveKeyWithValue("sslmode", "disable"),
+				HaveKeyWithValue("app", "white-plum"),
+			))
+			Expect(testedConfig.Pool.MaxSize).To(BeEquivalentTo(12))
+			Expect(testedConfig.Pool.MinSize).To(BeEquivalentTo(2))
+			Expect(testedConfig.Replica).NotTo(BeNil())
+			Expect(testedConfig.Replica.Host).To(BeEquivalentTo("10.71.8.92"))
+			Expect(testedConfig.Ignored).To(BeEmpty())
+		})
+
+		It("Binds properties(nested maps)", func() {
+			testedEnv := EnvBuilder.NewByMap(map[string]interface{} {
+				"db": map[string]interface{} {
+					"primary": map[string]interface{} {
+						"host": "10.71.8.93",
+						"pool": map[string]interface{} {
+							"max-size": 7,
+						},
+						"backups": []interface{} {
+							map[string]interface{} { "host": "10.71.9.1" },
+							map[string]interface{} { "host": "10.71.9.2", "port": 5439 },
+						},
+					},
+				},
+			})
+
+			var testedConfig sampleDbConfig
+			err := BindProperties(testedEnv, "db.primary", &testedConfig)
+
+			Expect(err).To(Succeed())
+			Expect(testedConfig.Host).To(BeEquivalentTo("10.71.8.93"))
+			Expect(testedConfig.Pool.MaxSize).To(BeEquivalentTo(7))
+			Expect(testedConfig.Replica).To(BeNil())
+			Expect(testedConfig.Backups).To(HaveLen(2))
+			Expect(testedConfig.Backups[1].Host).To(BeEquivalentTo("10.71.9.2"))
+			Expect(testedConfig.Backups[1].Port).To(BeEquivalentTo(5439))
+		})
+
+		It("Aggregated errors", func() {
+			testedEnv := EnvBuilder.NewByMap(map[string]interface{} {
+				"db.primary.port": "not-a-number",
+				"db.primary.timeout": "15 light years",
+				"db.primary.pool.max-size": 0,
+			})
+
+			var testedConfig sampleDbConfig
+			err := BindProperties(testedEnv, "db.primary", &testedConfig)
+
+			var bindingErr *BindingError
+			Expect(errors.As(err, &bindingErr)).To(BeTrue())
+
+			properties := make([]string, 0, len(bindingErr.FieldErrors))
+			for _, fieldErr := range bindingErr.FieldErrors {
+				properties = append(properties, fieldErr.Property)
+			}
+			Expect(properties).To(ConsistOf(
+				"db.primary.port", "db.primary.timeout",
+				"db.primary.host", "db.primary.pool.max-size",
+			))
+			Expect(err).To(MatchError(And(
+				MatchRegexp(`Property\[db.primary.host\]\(sampleDbConfig.Host\): property is required`),
+				MatchRegexp(`Property\[db.primary.pool.max-size\]\(sampleDbConfig.Pool.MaxSize\): validation failed on "min=1"`),
+			)))
+		})
+
+		DescribeTable("Invalid target",
+			func(target interface{}) {
+				err := BindProperties(EnvBuilder.NewByMap(map[string]interface{}{}), "db", target)
+
+				Expect(err).To(MatchError(MatchRegexp(`non-nil pointer to struct`)))
+			},
+			Entry("Not a pointer", sampleDbConfig{}),
+			Entry("Nil pointer", (*sampleDbConfig)(nil)),
+			Entry("Not a struct", new(int)),
+		)
+
+		It("Resolver cannot list its properties", func() {
+			err := BindProperties(
+				&sampleExternalResolver{ EnvBuilder.NewByMap(map[string]interface{}{}) },
+				"db", &sampleDbConfig{},
+			)
+
+			Expect(err).To(MatchError(ContainSubstring("cannot list its properties")))
+		})
+	})
+
+	DescribeTable("lookupProperty",
+		func(name string, expected interface{}, expectedFound bool) {
+			sampleProps := map[string]interface{} {
+				"k1.v1": 10,
+				"k2": map[string]interface{} {
+					"v1": map[string]interface{} { "z1": 20 },
+				},
+				"k3": "not-a-map",
+				"k4.camelcase": 40,
+			}
+
+			testedValue, found := lookupProperty(sampleProps, name)
+
+			Expect(found).To(BeEquivalentTo(expectedFound))
+			if expectedFound {
+				Expect(testedValue).To(BeEquivalentTo(expected))
+			}
+		},
+		Entry("Flatten key", "k1.v1", 10, true),
+		Entry("Nested map", "k2.v1.z1", 20, true),
+		Entry("Lower case", "k4.camelCase", 40, true),
+		Entry("Not existing", "k2.v1.z2", nil, false),
+		Entry("Parent is not a map", "k3.v1", nil, false),
+	)
+})
+
+type sampleDbConfig struct {
+	Host string `fg:"host" validate:"required"`
+	Port int `fg:"port:5432"`
+	User string `fg:"user:postgres"`
+	Timeout time.Duration `fg:"timeout:10s"`
+	BufferSize bs.ByteSize `fg:"buffer-size"`
+	Tags []string `fg:"tags"`
+	Ports []uint16 `fg:"ports"`
+	Options map[string]string `fg:"options"`
+	Pool struct {
+		MaxSize int `fg:"max-size:8" validate:"min=1"`
+		MinSize int `fg:"min-size:2"`
+	} `fg:"pool"`
+	Replica *sampleReplicaConfig `fg:"replica"`
+	Backups []sampleReplicaConfig `fg:"backups"`
+	Ignored string `fg:"-"`
+}
+type sampleReplicaConfig struct {
+	Host string `fg:"host"`
+	Port int `fg:"port:5432"`
+}
@@ -0,0 +1,360 @@
+package frangipani
+
+import (
+	"encoding"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cast"
+	bs "github.com/inhies/go-bytesize"
+)
+
+var (
+	typeOfDuration = reflect.TypeOf(time.Duration(0))
+	typeOfByteSize = reflect.TypeOf(bs.ByteSize(0))
+	typeOfTime = reflect.TypeOf(time.Time{})
+	typeOfString = reflect.TypeOf("")
+	typeOfInt = reflect.TypeOf(0)
+	typeOfStringSlice = reflect.TypeOf([]string{})
+	typeOfTextUnmarshaler = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// Converts value of property to the registered type(see "RegisterConverter()").
+type ConverterFunc func(value interface{}) (interface{}, error)
+
+var (
+	converters = make(map[reflect.Type]ConverterFunc)
+	convertersLock sync.RWMutex
+)
+
+// Registers the converter for the target type, which is used by "GetAs()" and "BindProperties()".
+//
+// The registered converter of pointer type(e.g., "*url.URL") is used for the element type("url.URL") as well.
+//
+// Built-in converters:
+//
+//   *url.URL - By "url.Parse()"
+//   *net.IPNet - By "net.ParseCIDR()", e.g., "10.0.0.0/8"
+//   *regexp.Regexp - By "regexp.Compile()"
+//   *time.Location - By "time.LoadLocation()", e.g., "Asia/Taipei"
+//   os.FileMode - The octal text, e.g., "0644"
+//
+// Besides registered converters, the types implementing "encoding.TextUnmarshaler"(e.g., "net.IP", "big.Int")
+// are converted by text of value.
+func RegisterConverter(targetType reflect.Type, converter ConverterFunc) {
+	convertersLock.Lock()
+	defer convertersLock.Unlock()
+
+	converters[targetType] = converter
+}
+
+func getConverter(targetType reflect.Type) (ConverterFunc, bool) {
+	convertersLock.RLock()
+	defer convertersLock.RUnlock()
+
+	converter, ok := converters[targetType]
+	return converter, ok
+}
+
+// Checks whether or not the type(or its pointer type) has registered converter or implements "encoding.TextUnmarshaler"
+func hasConverter(targetType reflect.Type) bool {
+	if _, ok := getConverter(targetType); ok {
+		return true
+	}
+	if _, ok := getConverter(reflect.PtrTo(targetType)); ok {
+		return true
+	}
+
+	return reflect.PtrTo(targetType).Implements(typeOfTextUnmarshaler)
+}
+
+func init() {
+	RegisterConverter(reflect.TypeOf(&url.URL{}), func(value interface{}) (interface{}, error) {
+		return url.Parse(cast.ToString(value))
+	})
+	RegisterConverter(reflect.TypeOf(&net.IPNet{}), func(value interface{}) (interface{}, error) {
+		_, ipNet, err := net.ParseCIDR(cast.ToString(value))
+		return ipNet, err
+	})
+	RegisterConverter(reflect.TypeOf(&regexp.Regexp{}), func(value interface{}) (interface{}, error) {
+		return regexp.Compile(cast.ToString(value))
+	})
+	RegisterConverter(reflect.TypeOf(&time.Location{}), func(value interface{}) (interface{}, error) {
+		return time.LoadLocation(cast.ToString(value))
+	})
+	RegisterConverter(reflect.TypeOf(os.FileMode(0)), func(value interface{}) (interface{}, error) {
+		text, ok := value.(string)
+		if !ok {
+			mode, err := cast.ToUint32E(value)
+			return os.FileMode(mode), err
+		}
+
+		mode, err := strconv.ParseUint(strings.TrimPrefix(strings.TrimPrefix(text, "0o"), "0O"), 8, 32)
+		return os.FileMode(mode), err
+	})
+}
+
+// Converts value of property to the target type.
+//
+// The conversions are as same as "TypedR", while the named types(e.g., "type Port int") are supported.
+func convertValue(value interface{}, targetType reflect.Type) (reflect.Value, error) {
+	if value == nil {
+		return reflect.Zero(targetType), nil
+	}
+
+	/**
+	 * Uses the registered converters
+	 */
+	if converter, ok := getConverter(targetType); ok {
+		return convertByConverter(converter, value, targetType)
+	}
+	if converter, ok := getConverter(reflect.PtrTo(targetType)); ok {
+		ptrValue, err := convertByConverter(converter, value, reflect.PtrTo(targetType))
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return ptrValue.Elem(), nil
+	}
+	// :~)
+
+	/**
+	 * Types having dedicated conversions
+	 */
+	switch targetType {
+	case typeOfDuration:
+		return convertByCast(cast.ToDurationE, value, targetType)
+	case typeOfTime:
+		return convertByCast(cast.ToTimeE, value, targetType)
+	case typeOfByteSize:
+		if byteSize, ok := value.(bs.ByteSize); ok {
+			return reflect.ValueOf(byteSize), nil
+		}
+
+		byteSize, err := bs.Parse(cast.ToString(value))
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(byteSize), nil
+	}
+
+	if reflect.PtrTo(targetType).Implements(typeOfTextUnmarshaler) && targetType.Kind() != reflect.Ptr {
+		return convertByTextUnmarshaler(value, targetType)
+	}
+	// :~)
+
+	switch targetType.Kind() {
+	case reflect.Bool:
+		return convertByCast(cast.ToBoolE, value, targetType)
+	case reflect.Int:
+		return convertByCast(cast.ToIntE, value, targetType)
+	case reflect.Int8:
+		return convertByCast(cast.ToInt8E, value, targetType)
+	case reflect.Int16:
+		return convertByCast(cast.ToInt16E, value, targetType)
+	case reflect.Int32:
+		return convertByCast(cast.ToInt32E, value, targetType)
+	case reflect.Int64:
+		return convertByCast(cast.ToInt64E, value, targetType)
+	case reflect.Uint:
+		return convertByCast(cast.ToUintE, value, targetType)
+	case reflect.Uint8:
+		return convertByCast(cast.ToUint8E, value, targetType)
+	case reflect.Uint16:
+		return convertByCast(cast.ToUint16E, value, targetType)
+	case reflect.Uint32:
+		return convertByCast(cast.ToUint32E, value, targetType)
+	case reflect.Uint64:
+		return convertByCast(cast.ToUint64E, value, targetType)
+	case reflect.Float32:
+		return convertByCast(cast.ToFloat32E, value, targetType)
+	case reflect.Float64:
+		return convertByCast(cast.ToFloat64E, value, targetType)
+	case reflect.String:
+		return convertByCast(cast.ToStringE, value, targetType)
+	case reflect.Interface:
+		if reflect.TypeOf(value).Implements(targetType) {
+			return reflect.ValueOf(value), nil
+		}
+	case reflect.Ptr:
+		elemValue, err := convertValue(value, targetType.Elem())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		ptrValue := reflect.New(targetType.Elem())
+		ptrValue.Elem().Set(elemValue)
+		return ptrValue, nil
+	case reflect.Slice:
+		return convertSlice(value, targetType)
+	case reflect.Map:
+		return convertMap(value, targetType)
+	case reflect.Struct:
+		return convertStruct(value, targetType)
+	}
+
+	return reflect.Value{}, fmt.Errorf("unable to convert %#v of type %T to %v", value, value, targetType)
+}
+
+func convertSlice(value interface{}, targetType reflect.Type) (reflect.Value, error) {
+	/**
+	 * Uses the conversions of "TypedR"
+	 *
+	 * For named types of element(e.g., "[]Color"), the elements are converted one by one.
+	 */
+	var castFunc interface{}
+	switch targetType.Elem().Kind() {
+	case reflect.String:
+		castFunc = cast.ToStringSliceE
+	case reflect.Int:
+		castFunc = cast.ToIntSliceE
+	}
+
+	if castFunc != nil {
+		castValue, err := convertByCast(castFunc, value, nil)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		if elemType := targetType.Elem(); elemType == typeOfString || elemType == typeOfInt {
+			return castValue.Convert(targetType), nil
+		}
+
+		value = castValue.Interface()
+	}
+	// :~)
+
+	sourceValue := reflect.ValueOf(value)
+	if kind := sourceValue.Kind(); kind != reflect.Slice && kind != reflect.Array {
+		sourceValue = reflect.ValueOf([]interface{}{ value })
+	}
+
+	result := reflect.MakeSlice(targetType, sourceValue.Len(), sourceValue.Len())
+	for i := 0; i < sourceValue.Len(); i++ {
+		elemValue, err := convertValue(sourceValue.Index(i).Interface(), targetType.Elem())
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("element[%d]: %w", i, err)
+		}
+
+		result.Index(i).Set(elemValue)
+	}
+
+	return result, nil
+}
+
+func convertMap(value interface{}, targetType reflect.Type) (reflect.Value, error) {
+	if targetType.Key().Kind() != reflect.String {
+		return reflect.Value{}, fmt.Errorf("unsupported type of key for map: %v", targetType)
+	}
+
+	/**
+	 * Uses the conversions of "TypedR"
+	 *
+	 * For named types of element(e.g., "map[string]Color"), the elements are converted one by one.
+	 */
+	elemType := targetType.Elem()
+	var castFunc interface{}
+	switch {
+	case elemType.Kind() == reflect.String:
+		castFunc = cast.ToStringMapStringE
+	case elemType.Kind() == reflect.Slice && elemType.Elem().Kind() == reflect.String:
+		castFunc = cast.ToStringMapStringSliceE
+	}
+
+	if castFunc != nil {
+		castValue, err := convertByCast(castFunc, value, nil)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		if targetType.Key() == typeOfString && (elemType == typeOfString || elemType == typeOfStringSlice) {
+			return castValue.Convert(targetType), nil
+		}
+
+		castMap := make(map[string]interface{}, castValue.Len())
+		iter := castValue.MapRange()
+		for iter.Next() {
+			castMap[iter.Key().String()] = iter.Value().Interface()
+		}
+		value = castMap
+	}
+	// :~)
+
+	sourceMap, err := cast.ToStringMapE(value)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	result := reflect.MakeMapWithSize(targetType, len(sourceMap))
+	for k, v := range sourceMap {
+		elemValue, err := convertValue(v, elemType)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("element[%s]: %w", k, err)
+		}
+
+		result.SetMapIndex(reflect.ValueOf(k).Convert(targetType.Key()), elemValue)
+	}
+
+	return result, nil
+}
+
+// Converts value by a "ToXXXE" function of "spf13/cast", the result is converted to the target type(if it is not nil).
+func convertByCast(castFunc interface{}, value interface{}, targetType reflect.Type) (reflect.Value, error) {
+	result := reflect.ValueOf(castFunc).Call(
+		[]reflect.Value{ reflect.ValueOf(value) },
+	)
+
+	if err, _ := result[1].Interface().(error); err != nil {
+		return reflect.Value{}, err
+	}
+
+	if targetType == nil {
+		return result[0], nil
+	}
+
+	return result[0].Convert(targetType), nil
+}
+
+func convertByConverter(converter ConverterFunc, value interface{}, targetType reflect.Type) (reflect.Value, error) {
+	result, err := converter(value)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	resultValue := reflect.ValueOf(result)
+	if !resultValue.IsValid() {
+		return reflect.Zero(targetType), nil
+	}
+	if !resultValue.Type().ConvertibleTo(targetType) {
+		return reflect.Value{}, fmt.Errorf("converted value of type %T is not convertible to %v", result, targetType)
+	}
+
+	return resultValue.Convert(targetType), nil
+}
+
+// The value is converted to text, then it is unmarshalled by the new instance of target type
+func convertByTextUnmarshaler(value interface{}, targetType reflect.Type) (reflect.Value, error) {
+	sourceValue := reflect.ValueOf(value)
+	if sourceValue.Type() == targetType {
+		return sourceValue, nil
+	}
+
+	text, err := cast.ToStringE(value)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	ptrValue := reflect.New(targetType)
+	if err := ptrValue.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(text)); err != nil {
+		return reflect.Value{}, err
+	}
+
+	return ptrValue.Elem(), nil
+}
@@ -0,0 +1,102 @@
+package frangipani
+
+import (
+	"fmt"
+	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	bs "github.com/inhies/go-bytesize"
+	"github.com/spf13/cast"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Conversion of values", func() {
+	type samplePort int
+	type sampleColor string
+	sampleBigInt, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+
+	DescribeTable("convertValue",
+		func(value interface{}, sampleType reflect.Type, expected interface{}) {
+			testedValue, err := convertValue(value, sampleType)
+
+			Expect(err).To(Succeed())
+			Expect(testedValue.Interface()).To(BeEquivalentTo(expected))
+		},
+		Entry("int", "20", reflect.TypeOf(0), 20),
+		Entry("named type", "8080", reflect.TypeOf(samplePort(0)), samplePort(8080)),
+		Entry("bool", "true", reflect.TypeOf(false), true),
+		Entry("float32", "1.5", reflect.TypeOf(float32(0)), float32(1.5)),
+		Entry("duration", "1m", reflect.TypeOf(time.Duration(0)), time.Minute),
+		Entry("byte size", "2 mb", reflect.TypeOf(bs.ByteSize(0)), 2 * bs.MB),
+		Entry("[]string", "a b", reflect.TypeOf([]string{}), []string{ "a", "b" }),
+		Entry("[]time.Duration", []interface{}{ "1s", "2s" }, reflect.TypeOf([]time.Duration{}), []time.Duration{ time.Second, 2 * time.Second }),
+		Entry("[]int64(single value)", 7, reflect.TypeOf([]int64{}), []int64{ 7 }),
+		Entry("map[string]int", map[string]interface{}{ "a": "1" }, reflect.TypeOf(map[string]int{}), map[string]int{ "a": 1 }),
+		Entry("[]named int", []interface{}{ 8080, "8081" }, reflect.TypeOf([]samplePort{}), []samplePort{ 8080, 8081 }),
+		Entry("[]named string", []interface{}{ "red", "blue" }, reflect.TypeOf([]sampleColor{}), []sampleColor{ "red", "blue" }),
+		Entry("[]named string(split by space)", "red blue", reflect.TypeOf([]sampleColor{}), []sampleColor{ "red", "blue" }),
+		Entry("map[string]named string", map[string]interface{}{ "bg": "red" }, reflect.TypeOf(map[string]sampleColor{}), map[string]sampleColor{ "bg": "red" }),
+		Entry("nil value", nil, reflect.TypeOf(0), 0),
+		Entry("file mode", "0644", reflect.TypeOf(os.FileMode(0)), os.FileMode(0644)),
+		Entry("file mode(number)", 420, reflect.TypeOf(os.FileMode(0)), os.FileMode(0644)),
+		Entry("net.IP(TextUnmarshaler)", "10.1.2.3", reflect.TypeOf(net.IP{}), net.ParseIP("10.1.2.3")),
+		Entry("big.Int(TextUnmarshaler)", "123456789012345678901234567890", reflect.TypeOf(big.Int{}), *sampleBigInt),
+		Entry("url.URL(by converter of pointer)", "https://example.com/a", reflect.TypeOf(url.URL{}), url.URL{ Scheme: "https", Host: "example.com", Path: "/a" }),
+	)
+
+	DescribeTable("Registered converters",
+		func(value interface{}, sampleType reflect.Type, expected string) {
+			testedValue, err := convertValue(value, sampleType)
+
+			Expect(err).To(Succeed())
+			Expect(fmt.Sprintf("%v", testedValue.Interface())).To(Equal(expected))
+		},
+		Entry("*url.URL", "pg://10.1.1.1:5432/db", reflect.TypeOf(&url.URL{}), "pg://10.1.1.1:5432/db"),
+		Entry("*net.IPNet", "10.20.0.0/16", reflect.TypeOf(&net.IPNet{}), "10.20.0.0/16"),
+		Entry("*regexp.Regexp", "^a+$", reflect.TypeOf(&regexp.Regexp{}), "^a+$"),
+		Entry("*time.Location", "Asia/Taipei", reflect.TypeOf(&time.Location{}), "Asia/Taipei"),
+	)
+
+	It("Custom converter", func() {
+		type celsius float64
+		RegisterConverter(reflect.TypeOf(celsius(0)), func(value interface{}) (interface{}, error) {
+			text := strings.TrimSuffix(cast.ToString(value), "C")
+			return cast.ToFloat64E(text)
+		})
+
+		testedValue, err := convertValue("36.5C", reflect.TypeOf(celsius(0)))
+
+		Expect(err).To(Succeed())
+		Expect(testedValue.Interface()).To(Equal(celsius(36.5)))
+	})
+
+	It("Pointer", func() {
+		testedValue, err := convertValue("33", reflect.TypeOf(new(int)))
+
+		Expect(err).To(Succeed())
+		Expect(*(testedValue.Interface().(*int))).To(BeEquivalentTo(33))
+	})
+
+	DescribeTable("Conversion error",
+		func(value interface{}, sampleType reflect.Type, expectedErr string) {
+			_, err := convertValue(value, sampleType)
+
+			Expect(err).To(MatchError(MatchRegexp(expectedErr)))
+		},
+		Entry("int", "x1", reflect.TypeOf(0), `unable to cast`),
+		Entry("byte size", "2 cm", reflect.TypeOf(bs.ByteSize(0)), `Unrecognized size suffix`),
+		Entry("element of slice", []interface{}{ 1, "x" }, reflect.TypeOf([]int32{}), `element\[1\]`),
+		Entry("key of map", map[string]interface{}{}, reflect.TypeOf(map[int]int{}), `unsupported type of key`),
+		Entry("unsupported type", 1, reflect.TypeOf(make(chan int)), `unable to convert`),
+		Entry("*regexp.Regexp", "(", reflect.TypeOf(&regexp.Regexp{}), `missing closing`),
+		Entry("net.IP", "10.1.1", reflect.TypeOf(net.IP{}), `invalid IP address`),
+	)
+})
@@ -0,0 +1,69 @@
+package dingo
+
+import (
+	"fmt"
+
+	"flamingo.me/dingo"
+
+	fg "github.com/mikelue/go-misc/ioc/frangipani"
+	fgenv "github.com/mikelue/go-misc/ioc/frangipani/env"
+)
+
+// Method space used to construct "AppContext" bootstrapped with "Environment"
+var AppContextBuilder IAppContextBuilder
+
+// Constructs "AppContext" by:
+//
+//   1. Loading the "Environment"(by "env.DefaultLoader" or a given "*env.ConfigBuilder")
+//   2. Binding the "Environment" into a new "*dingo.Injector"
+//   3. Installing the modules
+//
+// For example:
+//
+//   appContext, err := AppContextBuilder.New(new(DbModule), new(WebModule))
+type IAppContextBuilder int
+// Loads "Environment" by "env.DefaultLoader"(with parsed flags), the errors of loading are returned(see "ConfigLoader.TryLoad()").
+func (self *IAppContextBuilder) New(modules ...dingo.Module) (AppContext, error) {
+	return self.newByLoader(fgenv.DefaultLoader.New(), modules...)
+}
+// Loads "Environment" by the customized builder(with parsed flags), the errors of loading are returned(see "ConfigLoader.TryLoad()").
+func (self *IAppContextBuilder) NewByConfigBuilder(configBuilder *fgenv.ConfigBuilder, modules ...dingo.Module) (AppContext, error) {
+	return self.newByLoader(configBuilder.Build(), modules...)
+}
+// Uses the existing "Environment", which is useful for testing(e.g., "EnvBuilder.NewOverlay()").
+func (*IAppContextBuilder) NewByEnvironment(env fg.Environment, modules ...dingo.Module) (appContext AppContext, err error) {
+	injector, err := dingo.NewInjector()
+	if err != nil {
+		return nil, err
+	}
+
+	injector.Bind(new(fg.Environment)).ToInstance(env)
+
+	/**
+	 * The panic of configuring modules(e.g., "BindIf()" without "Environment") is returned as error
+	 */
+	defer func() {
+		if p := recover(); p != nil {
+			appContext, err = nil, fmt.Errorf("Installing modules has error: %v", p)
+		}
+	}()
+	// :~)
+
+	if err = injector.InitModules(modules...); err != nil {
+		return nil, fmt.Errorf("Installing modules has error: %w", err)
+	}
+
+	return AsAppContext(injector), nil
+}
+func (self *IAppContextBuilder) newByLoader(loader fgenv.ConfigLoader, modules ...dingo.Module) (AppContext, error) {
+	env, err := loader.ParseFlags().TryLoad()
+	if err != nil {
+		return nil, fmt.Errorf("Loading environment has error: %w", err)
+	}
+
+	return self.NewByEnvironment(env, modules...)
+}
+
+func init() {
+	AppContextBuilder = 0
+}
@@ -0,0 +1,74 @@
+package dingo
+
+import (
+	"os"
+
+	"flamingo.me/dingo"
+	"github.com/spf13/pflag"
+
+	fg "github.com/mikelue/go-misc/ioc/frangipani"
+	fgenv "github.com/mikelue/go-misc/ioc/frangipani/env"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AppContextBuilder", func() {
+	repositoryModule := dingo.ModuleFunc(func(injector *dingo.Injector) {
+		BindIf(injector, OnProfiles("test"), new(repository)).To(new(memoryRepository))
+		BindIf(injector, OnProfiles("!test"), new(repository)).To(new(gormRepository))
+	})
+
+	Context("NewByConfigBuilder", func() {
+		var oldOsArgs []string
+
+		BeforeEach(func() {
+			oldOsArgs = os.Args
+			os.Args = []string {
+				`--papaya.config.yaml={ server.port: 8081 }`,
+				`--papaya.profiles.active=test`,
+			}
+		})
+		AfterEach(func() {
+			os.Args = oldOsArgs
+		})
+
+		It("Bootstrapped context", func() {
+			testedContext, err := AppContextBuilder.NewByConfigBuilder(
+				fgenv.NewConfigBuilder().
+					Prefix("papaya").
+					Priority(fgenv.CL_ARGS).
+					Pflags(pflag.NewFlagSet("test-bootstrap", pflag.ContinueOnError)),
+				repositoryModule,
+			)
+
+			Expect(err).To(Succeed())
+			Expect(testedContext.Environment().Typed().GetInt("server.port")).To(Equal(8081))
+			Expect(testedContext.GetInstance(new(repository)).(repository).name()).To(Equal("memory"))
+		})
+	})
+
+	Context("NewByEnvironment", func() {
+		It("Bootstrapped context", func() {
+			testedContext, err := AppContextBuilder.NewByEnvironment(
+				fg.EnvBuilder.NewByMap(map[string]interface{} { "server.port": 8082 }),
+				repositoryModule,
+			)
+
+			Expect(err).To(Succeed())
+			Expect(testedContext.Environment().Typed().GetInt("server.port")).To(Equal(8082))
+			Expect(testedContext.GetInstance(new(repository)).(repository).name()).To(Equal("gorm"))
+		})
+
+		It("Panic of installing modules", func() {
+			_, err := AppContextBuilder.NewByEnvironment(
+				fg.EnvBuilder.NewByMap(map[string]interface{} {}),
+				dingo.ModuleFunc(func(injector *dingo.Injector) {
+					OnProfiles("(bad")
+				}),
+			)
+
+			Expect(err).To(MatchError(ContainSubstring("Installing modules has error")))
+		})
+	})
+})
@@ -0,0 +1,120 @@
+package dingo
+
+import (
+	"fmt"
+	"strings"
+
+	"flamingo.me/dingo"
+
+	fg "github.com/mikelue/go-misc/ioc/frangipani"
+)
+
+// The condition(on "Environment") of bindings or modules
+//
+// See: "BindIf()", "ConditionalModule()"
+type Condition func(env fg.Environment) bool
+
+// Matched if the expressions of profiles are accepted by the environment(see "frangipani.ParseProfiles()").
+//
+//   OnProfiles("test")
+//   OnProfiles("prod & (eu | us)")
+//
+// This function panics if any of the expressions is malformed.
+func OnProfiles(expressions ...string) Condition {
+	profiles, err := fg.ParseProfiles(expressions...)
+	if err != nil {
+		panic(err)
+	}
+
+	return func(env fg.Environment) bool {
+		return env.AcceptsProfiles(profiles)
+	}
+}
+
+// Matched if the value of property is equal to the expected one(case-insensitive), e.g., OnProperty("feature.x.enabled", "true")
+func OnProperty(name string, expectedValue string) Condition {
+	return func(env fg.Environment) bool {
+		return env.ContainsProperty(name) &&
+			strings.EqualFold(strings.TrimSpace(env.GetProperty(name)), expectedValue)
+	}
+}
+
+// Matched if the property is not existing
+func OnMissingProperty(name string) Condition {
+	return func(env fg.Environment) bool {
+		return !env.ContainsProperty(name)
+	}
+}
+
+// Matched if the condition is not matched
+func Negate(condition Condition) Condition {
+	return func(env fg.Environment) bool {
+		return !condition(env)
+	}
+}
+
+// Matched if all of the conditions are matched
+func AllOf(conditions ...Condition) Condition {
+	return func(env fg.Environment) bool {
+		for _, condition := range conditions {
+			if !condition(env) {
+				return false
+			}
+		}
+
+		return true
+	}
+}
+
+// Binds the type if the condition is matched by the "Environment"(must be bound before), which is used in "Module.Configure()".
+//
+//   func (*RepositoryModule) Configure(injector *dingo.Injector) {
+//     fgdingo.BindIf(injector, fgdingo.OnProfiles("test"), new(Repository)).To(new(memoryRepository))
+//     fgdingo.BindIf(injector, fgdingo.OnProfiles("prod"), new(Repository)).To(new(gormRepository))
+//     fgdingo.BindIf(injector, fgdingo.OnProperty("feature.x.enabled", "true"), new(FeatureX)).In(dingo.Singleton)
+//   }
+//
+// If the condition is not matched, the returned binding belongs to a detached injector(the configuration of it takes no effect).
+//
+// This function panics if the "Environment" is not bound.
+func BindIf(injector *dingo.Injector, condition Condition, what interface{}) *dingo.Binding {
+	if condition(mustGetEnvironment(injector)) {
+		return injector.Bind(what)
+	}
+
+	detachedInjector, err := dingo.NewInjector()
+	if err != nil {
+		panic(err)
+	}
+
+	return detachedInjector.Bind(what)
+}
+
+// Constructs a module, which installs the modules only if the condition is matched by the "Environment"(must be bound before).
+//
+//   injector.InitModules(
+//     fgdingo.ConditionalModule(fgdingo.OnProfiles("test"), new(InMemoryModule)),
+//     fgdingo.ConditionalModule(fgdingo.OnProfiles("!test"), new(GormModule)),
+//   )
+//
+// The configuring of the module panics if the "Environment" is not bound.
+func ConditionalModule(condition Condition, modules ...dingo.Module) dingo.Module {
+	return dingo.ModuleFunc(func(injector *dingo.Injector) {
+		if !condition(mustGetEnvironment(injector)) {
+			return
+		}
+
+		if err := injector.InitModules(modules...); err != nil {
+			panic(err)
+		}
+	})
+}
+
+func mustGetEnvironment(injector *dingo.Injector) fg.Environment {
+	env, err := injector.GetInstance(new(fg.Environment))
+	if err != nil {
+		panic(fmt.Errorf("Environment must be bound before conditional bindings: %w", err))
+	}
+
+	return env.(fg.Environment)
+}
@@ -0,0 +1,94 @@
+package dingo
+
+import (
+	"flamingo.me/dingo"
+
+	fg "github.com/mikelue/go-misc/ioc/frangipani"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Conditional bindings", func() {
+	newInjector := func(props map[string]interface{}) *dingo.Injector {
+		injector, err := dingo.NewInjector()
+		if err != nil {
+			panic(err)
+		}
+
+		injector.Bind(new(fg.Environment)).ToInstance(fg.EnvBuilder.NewByMap(props))
+		return injector
+	}
+
+	DescribeTable("BindIf",
+		func(props map[string]interface{}, expected string) {
+			injector := newInjector(props)
+
+			BindIf(injector, OnProfiles("test"), new(repository)).To(new(memoryRepository))
+			BindIf(injector, OnProfiles("prod & !test"), new(repository)).To(new(gormRepository))
+
+			testedRepository := AsAppContext(injector).GetInstance(new(repository)).(repository)
+			Expect(testedRepository.name()).To(Equal(expected))
+		},
+		Entry("Profile of test", map[string]interface{} { fg.PROP_ACITVE_PROFILES: "test" }, "memory"),
+		Entry("Profile of prod", map[string]interface{} { fg.PROP_ACITVE_PROFILES: "prod" }, "gorm"),
+	)
+
+	DescribeTable("ConditionalModule",
+		func(props map[string]interface{}, expected bool) {
+			injector := newInjector(props)
+
+			Expect(injector.InitModules(
+				ConditionalModule(OnProperty("feature.x.enabled", "true"),
+					dingo.ModuleFunc(func(injector *dingo.Injector) {
+						injector.Bind(new(repository)).To(new(memoryRepository))
+					}),
+				),
+			)).To(Succeed())
+
+			_, err := injector.GetInstance(new(repository))
+			Expect(err == nil).To(Equal(expected))
+		},
+		Entry("Enabled", map[string]interface{} { "feature.x.enabled": "TRUE" }, true),
+		Entry("Disabled", map[string]interface{} { "feature.x.enabled": false }, false),
+		Entry("Missing", map[string]interface{} {}, false),
+	)
+
+	DescribeTable("Conditions",
+		func(condition Condition, expected bool) {
+			testedEnv := fg.EnvBuilder.NewByMap(map[string]interface{} {
+				fg.PROP_ACITVE_PROFILES: "cloud,eu",
+				"cache.type": "redis",
+			})
+
+			Expect(condition(testedEnv)).To(Equal(expected))
+		},
+		Entry("Profiles(matched)", OnProfiles("cloud & (eu | us)"), true),
+		Entry("Profiles(not matched)", OnProfiles("prod"), false),
+		Entry("Property(matched)", OnProperty("cache.type", "Redis"), true),
+		Entry("Property(not matched)", OnProperty("cache.type", "memory"), false),
+		Entry("Missing property", OnMissingProperty("cache.size"), true),
+		Entry("Negate", Negate(OnProfiles("prod")), true),
+		Entry("AllOf", AllOf(OnProfiles("eu"), OnProperty("cache.type", "redis")), true),
+	)
+
+	It("Environment is not bound", func() {
+		injector, _ := dingo.NewInjector()
+
+		Expect(func() {
+			BindIf(injector, OnProfiles("test"), new(repository))
+		}).To(PanicWith(MatchError(ContainSubstring("Environment must be bound"))))
+	})
+})
+
+type repository interface {
+	name() string
+}
+type memoryRepository struct {}
+func (*memoryRepository) name() string {
+	return "memory"
+}
+type gormRepository struct {}
+func (*gormRepository) name() string {
+	return "gorm"
+}
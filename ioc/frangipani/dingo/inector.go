@@ -1,6 +1,12 @@
 /*
 This package provides some convenient method for utilizing dependency injection of dingo(flamingo.me).
 
+Bootstrapping
+
+"AppContextBuilder" loads "Environment", binds it into a new injector, and installs modules:
+
+  appContext, err := AppContextBuilder.New(new(DbModule), new(WebModule))
+
 AppContext
 
 This interface would be used for getting instance of managed instance with panic if something gets wrong.
@@ -8,24 +14,79 @@ This interface would be used for getting instance of managed instance with panic
   appContext := AsAppContext(injector)
   instance := appContext.GetInstance(new(yourType))
   env := appContext.Environment()
+
+Lookups with errors
+
+"TryGetInstance()"/"TryGetAnnotatedInstance()" and the generic "Get()"/"GetAnnotated()" return errors rather than panic,
+the "*ResolutionError" contains the chain of bindings to the failed one:
+
+  server, err := Get[*server](appContext)
+  repository, err := GetAnnotated[Repository](appContext, "primary")
+  obj, err := TryGetInstance(appContext, new(server))
+
+  // Resolving [*main.server -> *main.dbClient -> main.Pool] has error: ...
+
+Injection of properties
+
+The fields tagged by "fg" of structs(got by "AppContext.GetInstance()") are injected with properties of "Environment":
+
+  type server struct {
+    Port int `fg:"server.port:8080"`
+    Timeout time.Duration `fg:"server.timeout"`
+    Db *dbClient `inject:""`
+  }
+
+The dependencies(fields tagged by "inject") are injected as well, see "InjectProperties()" for details.
+Every object is injected once, after it is constructed by dingo(providers should use "InjectProperties()" by themselves).
+
+Lifecycle of components
+
+The components implementing "Initializer" are initialized after injection(by "AppContext.GetInstance()"),
+and the ones implementing "Disposer" are disposed by "Close()" in reverse dependency order:
+
+  func (self *dbPool) Init(ctx context.Context) error { ... }
+  func (self *dbPool) Dispose(ctx context.Context) error { ... }
+
+  defer Close(ctx, appContext)
+
+Conditional bindings
+
+The bindings(or modules) could be conditional on profiles or properties of the bound "Environment":
+
+  BindIf(injector, OnProfiles("test"), new(Repository)).To(new(memoryRepository))
+  BindIf(injector, OnProfiles("prod"), new(Repository)).To(new(gormRepository))
+
+  injector.InitModules(
+    ConditionalModule(OnProperty("feature.x.enabled", "true"), new(FeatureXModule)),
+  )
 */
 package dingo
 
 import (
+	"fmt"
+	"reflect"
+
 	"flamingo.me/dingo"
 	fg "github.com/mikelue/go-misc/ioc/frangipani"
 )
 
 // Gets an "AppContext" by a "*dingo.Injector"
+//
+// The lifecycle(see "Initializer" and "Disposer") of components is managed by the returned "AppContext",
+// the components(e.g., singletons) should be got by the same "AppContext" to be initialized only once.
 func AsAppContext(injector *dingo.Injector) AppContext {
-	return (*appContextImpl)(injector)
+	return &appContextImpl{ injector, newComponentRegistry() }
 }
 
 // Main enhanced interface for "dingo"
 //
 // See: https://github.com/i-love-flamingo/dingo
 type AppContext interface {
-	// Gets instance of an object/interface
+	// Gets instance of an object/interface, the properties are injected to fields tagged by "fg"(see "InjectProperties()")
+	//
+	// The "Initializer.Init()" of the object(and its dependencies) is called(with "context.Background()") after injection.
+	//
+	// See "TryGetInstance()" for getting instance with error rather than panic.
 	GetInstance(interface{}) interface{}
 	// Gets object of "Environment"
 	//
@@ -33,24 +94,81 @@ type AppContext interface {
 	Environment() fg.Environment
 }
 
-type appContextImpl dingo.Injector
+type appContextImpl struct {
+	injector *dingo.Injector
+	registry *componentRegistry
+}
 func (self *appContextImpl) GetInstance(of interface{}) interface{} {
-	injector := (*dingo.Injector)(self)
-
-	obj, err := injector.GetInstance(of)
+	obj, err := self.tryGetAnnotatedInstance(of, "")
 	if err != nil {
 		panic(err)
 	}
 
 	return obj
 }
-func (self *appContextImpl) Environment() fg.Environment {
-	injector := (*dingo.Injector)(self)
+func (self *appContextImpl) tryGetAnnotatedInstance(of interface{}, annotation string) (interface{}, error) {
+	obj, err := self.injector.GetAnnotatedInstance(of, annotation)
+	if err != nil {
+		return nil, &ResolutionError {
+			Chain: diagnose(requestedType(of), annotation, err),
+			Err: err,
+		}
+	}
+
+	if err := self.manage(obj, annotation); err != nil {
+		return nil, err
+	}
 
-	obj, err := injector.GetInstance(new(fg.Environment))
+	return obj, nil
+}
+func (self *appContextImpl) Environment() fg.Environment {
+	obj, err := self.injector.GetInstance(new(fg.Environment))
 	if err != nil {
 		panic(err)
 	}
 
 	return obj.(fg.Environment)
 }
+// Injects properties to the object and its dependencies, then initializes the components(see "Initializer")
+func (self *appContextImpl) manage(obj interface{}, annotation string) error {
+	registry := self.registry
+	registry.lock.Lock()
+	defer registry.lock.Unlock()
+
+	envSupplier := func() (fg.Environment, error) {
+		env, err := self.injector.GetInstance(new(fg.Environment))
+		if err != nil {
+			return nil, fmt.Errorf("Environment is needed for injecting properties: %w", err)
+		}
+
+		return env.(fg.Environment), nil
+	}
+
+	return newObjectGraphWalker(func(target reflect.Value) error {
+		/**
+		 * Every object is injected(and initialized) only once,
+		 * so the fields of shared objects(e.g., singletons) would not be rewritten by later lookups.
+		 *
+		 * The object is managed only if it is initialized successfully(the failed one is tried again by later lookups).
+		 */
+		if !needsManagement(target) {
+			return nil
+		}
+
+		key := objectKey{ target.Type(), target.Pointer() }
+		if _, ok := registry.managed[key]; ok {
+			return nil
+		}
+
+		if err := injectProperties(envSupplier, target); err != nil {
+			return err
+		}
+		if err := registry.initialize(target); err != nil {
+			return err
+		}
+
+		registry.managed[key] = target
+		return nil
+		// :~)
+	}).walk(reflect.ValueOf(obj), annotation)
+}
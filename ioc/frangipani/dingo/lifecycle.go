@@ -0,0 +1,117 @@
+package dingo
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Implemented by components which need initialization after injection(by "AppContext.GetInstance()").
+//
+// The dependencies(fields tagged by "inject") are initialized before the component.
+type Initializer interface {
+	Init(ctx context.Context) error
+}
+
+// Implemented by components which need to release resources(e.g., pools of database) while "Close()".
+//
+// The dependents are disposed before their dependencies.
+type Disposer interface {
+	Dispose(ctx context.Context) error
+}
+
+// The error of "Close()", which contains errors of all failed components.
+type DisposingError struct {
+	Errors []error
+}
+func (self *DisposingError) Error() string {
+	messages := make([]string, 0, len(self.Errors) + 1)
+	messages = append(messages, fmt.Sprintf("Disposing components has [%d] error(s):", len(self.Errors)))
+
+	for _, err := range self.Errors {
+		messages = append(messages, "\t" + err.Error())
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+// The state of lifecycle of components, which is held by an "AppContext"
+type componentRegistry struct {
+	lock sync.Mutex
+	// The objects having been injected(the values keep the objects from being collected, whose addresses are the keys)
+	managed map[objectKey]reflect.Value
+	// Ordered by initialization(dependencies first)
+	disposers []Disposer
+}
+
+func newComponentRegistry() *componentRegistry {
+	return &componentRegistry {
+		managed: make(map[objectKey]reflect.Value),
+	}
+}
+
+// Only the objects having properties(fields tagged by "fg") or lifecycle("Initializer" or "Disposer") are managed,
+// so the plain objects(e.g., unscoped ones got per request) are not kept by the registry.
+func needsManagement(target reflect.Value) bool {
+	switch target.Interface().(type) {
+	case Initializer, Disposer:
+		return true
+	}
+
+	return hasPropertyFields(target.Type().Elem())
+}
+
+// Initializes the component if it is "Initializer", the "Disposer" is kept for "Close()"
+func (self *componentRegistry) initialize(target reflect.Value) error {
+	component := target.Interface()
+
+	if initializer, ok := component.(Initializer); ok {
+		if err := initializer.Init(context.Background()); err != nil {
+			return fmt.Errorf("Initializing [%T] has error: %w", component, err)
+		}
+	}
+
+	if disposer, ok := component.(Disposer); ok {
+		self.disposers = append(self.disposers, disposer)
+	}
+
+	return nil
+}
+
+// Calls "Disposer.Dispose()" of initialized components in reverse dependency order(the dependents are disposed first).
+//
+// Every component is disposed even if some of them fail, "*DisposingError" is returned for the failed ones.
+//
+// Only the components got by the "AppContext" are disposed, the components got later are managed as new ones.
+// Nothing is disposed for the "AppContext" not built by this package.
+func Close(ctx context.Context, appContext AppContext) error {
+	if impl, ok := appContext.(*appContextImpl); ok {
+		return impl.close(ctx)
+	}
+
+	return nil
+}
+
+func (self *appContextImpl) close(ctx context.Context) error {
+	registry := self.registry
+	registry.lock.Lock()
+	defer registry.lock.Unlock()
+
+	errs := make([]error, 0)
+	for i := len(registry.disposers) - 1; i >= 0; i-- {
+		if err := registry.disposers[i].Dispose(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("Disposing [%T] has error: %w", registry.disposers[i], err))
+		}
+	}
+
+	registry.managed = make(map[objectKey]reflect.Value)
+	registry.disposers = nil
+
+	if len(errs) > 0 {
+		return &DisposingError{ errs }
+	}
+
+	return nil
+}
@@ -0,0 +1,162 @@
+package dingo
+
+import (
+	"context"
+	"errors"
+
+	"flamingo.me/dingo"
+
+	fg "github.com/mikelue/go-misc/ioc/frangipani"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Lifecycle of components", func() {
+	var events []string
+	var testedContext AppContext
+
+	BeforeEach(func() {
+		events = make([]string, 0)
+
+		injector, _ := dingo.NewInjector()
+		injector.Bind(new(fg.Environment)).ToInstance(fg.EnvBuilder.NewByMap(map[string]interface{} {
+			"pool.size": 8,
+		}))
+		injector.Bind(new(lifecyclePool)).ToInstance(&lifecyclePool{ events: &events })
+		injector.Bind(new(lifecycleClient)).ToProvider(func(pool *lifecyclePool) *lifecycleClient {
+			return &lifecycleClient{ Pool: pool, events: &events }
+		}).In(dingo.Singleton)
+		injector.Bind(new(lifecycleService)).In(dingo.Singleton)
+
+		testedContext = AsAppContext(injector)
+	})
+	AfterEach(func() {
+		Close(context.Background(), testedContext)
+	})
+
+	It("Init(after injection) and Dispose(reverse dependency order)", func() {
+		testedService := testedContext.GetInstance(new(lifecycleService)).(*lifecycleService)
+		testedContext.GetInstance(new(lifecycleService))
+
+		Expect(testedService.Pool.initializedSize).To(Equal(8))
+		Expect(events).To(Equal([]string{ "init:pool", "init:client" }))
+
+		Expect(Close(context.Background(), testedContext)).To(Succeed())
+		Expect(events).To(Equal([]string{
+			"init:pool", "init:client",
+			"dispose:client", "dispose:pool",
+		}))
+	})
+
+	It("Only components having properties or lifecycle are managed", func() {
+		injector, _ := dingo.NewInjector()
+		injector.Bind(new(fg.Environment)).ToInstance(fg.EnvBuilder.NewByMap(map[string]interface{} {}))
+		injector.Bind(new(lifecyclePool)).ToInstance(&lifecyclePool{ events: &events })
+		testedContext := AsAppContext(injector)
+
+		for i := 0; i < 3; i++ {
+			testedContext.GetInstance(new(lifecycleRequest))
+		}
+
+		registry := testedContext.(*appContextImpl).registry
+		Expect(registry.managed).To(HaveLen(1), "Only the pool is managed")
+		Expect(events).To(Equal([]string{ "init:pool" }))
+
+		Expect(Close(context.Background(), testedContext)).To(Succeed())
+		Expect(registry.managed).To(BeEmpty())
+		Expect(events).To(Equal([]string{ "init:pool", "dispose:pool" }))
+	})
+
+	It("Close of AppContext implemented outside of this package", func() {
+		testedContext.GetInstance(new(lifecycleService))
+
+		Expect(Close(context.Background(), &sampleExternalContext{ testedContext })).To(Succeed())
+		Expect(events).To(Equal([]string{ "init:pool", "init:client" }), "Nothing is disposed")
+	})
+
+	It("Errors of disposing", func() {
+		testedContext.GetInstance(new(lifecycleService)).(*lifecycleService).Pool.failed = true
+
+		var testedErr *DisposingError
+		err := Close(context.Background(), testedContext)
+
+		Expect(errors.As(err, &testedErr)).To(BeTrue())
+		Expect(testedErr.Errors).To(HaveLen(1))
+		Expect(events).To(ContainElements("dispose:client", "dispose:pool"))
+	})
+
+	It("Error of initializing", func() {
+		failedPool := &lifecyclePool{ events: &events, failed: true }
+
+		injector, _ := dingo.NewInjector()
+		injector.Bind(new(fg.Environment)).ToInstance(fg.EnvBuilder.NewByMap(map[string]interface{} {}))
+		injector.Bind(new(lifecyclePool)).ToInstance(failedPool)
+		testedContext := AsAppContext(injector)
+
+		Expect(func() {
+			testedContext.GetInstance(new(lifecyclePool))
+		}).To(PanicWith(MatchError(ContainSubstring("Initializing [*dingo.lifecyclePool] has error"))))
+
+		/**
+		 * The failed component is initialized again by later lookups
+		 */
+		Expect(func() {
+			testedContext.GetInstance(new(lifecyclePool))
+		}).To(PanicWith(MatchError(ContainSubstring("Initializing [*dingo.lifecyclePool] has error"))))
+
+		failedPool.failed = false
+		Expect(testedContext.GetInstance(new(lifecyclePool))).To(BeIdenticalTo(failedPool))
+		Expect(events).To(Equal([]string{ "init:pool" }))
+		// :~)
+	})
+})
+
+type lifecyclePool struct {
+	Size int `fg:"pool.size:4"`
+
+	events *[]string
+	initializedSize int
+	failed bool
+}
+func (self *lifecyclePool) Init(ctx context.Context) error {
+	if self.failed {
+		return errors.New("cannot connect")
+	}
+
+	self.initializedSize = self.Size
+	*self.events = append(*self.events, "init:pool")
+	return nil
+}
+func (self *lifecyclePool) Dispose(ctx context.Context) error {
+	*self.events = append(*self.events, "dispose:pool")
+	if self.failed {
+		return errors.New("cannot close")
+	}
+
+	return nil
+}
+
+type lifecycleClient struct {
+	Pool *lifecyclePool `inject:""`
+
+	events *[]string
+}
+func (self *lifecycleClient) Init(ctx context.Context) error {
+	*self.events = append(*self.events, "init:client")
+	return nil
+}
+func (self *lifecycleClient) Dispose(ctx context.Context) error {
+	*self.events = append(*self.events, "dispose:client")
+	return nil
+}
+
+type lifecycleService struct {
+	Client *lifecycleClient `inject:""`
+	Pool *lifecyclePool `inject:""`
+}
+
+// The plain object(unscoped) which is not managed
+type lifecycleRequest struct {
+	Pool *lifecyclePool `inject:""`
+}
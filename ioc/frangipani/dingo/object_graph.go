@@ -0,0 +1,116 @@
+package dingo
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Name of tag used by dingo to inject dependencies
+const TAG_INJECT = "inject"
+
+// Walks the graph of objects through fields tagged by "inject"(exported ones),
+// the visitor is called with every pointer to struct after its dependencies.
+//
+// The error of visitor is wrapped as "*ResolutionError" with the chain of bindings.
+type objectGraphWalker struct {
+	visited map[objectKey]bool
+	visitor func(reflect.Value) error
+	// The bindings from the walked object to the current one
+	chain []string
+}
+
+type objectKey struct {
+	objectType reflect.Type
+	pointer uintptr
+}
+
+func newObjectGraphWalker(visitor func(reflect.Value) error) *objectGraphWalker {
+	return &objectGraphWalker {
+		visited: make(map[objectKey]bool),
+		visitor: visitor,
+	}
+}
+func (self *objectGraphWalker) walk(value reflect.Value, annotation string) error {
+	switch value.Kind() {
+	case reflect.Interface:
+		if value.IsNil() {
+			return nil
+		}
+
+		return self.walk(value.Elem(), annotation)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if err := self.walk(value.Index(i), annotation); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case reflect.Map:
+		iter := value.MapRange()
+		for iter.Next() {
+			if err := self.walk(iter.Value(), annotation); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case reflect.Ptr:
+	default:
+		return nil
+	}
+
+	if value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+
+	key := objectKey{ value.Type(), value.Pointer() }
+	if self.visited[key] {
+		return nil
+	}
+	self.visited[key] = true
+
+	self.chain = append(self.chain, describeBinding(value.Type(), annotation))
+	defer func() {
+		self.chain = self.chain[:len(self.chain) - 1]
+	}()
+
+	/**
+	 * Walks dependencies first
+	 */
+	structValue := value.Elem()
+	for i := 0; i < structValue.NumField(); i++ {
+		field := structValue.Type().Field(i)
+		tag, ok := field.Tag.Lookup(TAG_INJECT)
+		if !ok || field.PkgPath != "" {
+			continue
+		}
+
+		fieldAnnotation, _ := parseInjectTag(tag)
+		if err := self.walk(structValue.Field(i), fieldAnnotation); err != nil {
+			return err
+		}
+	}
+	// :~)
+
+	if err := self.visitor(value); err != nil {
+		return &ResolutionError {
+			Chain: append([]string{}, self.chain...),
+			Err: err,
+		}
+	}
+
+	return nil
+}
+
+// Parses the tag of dingo as "<annotation>[,optional]"
+func parseInjectTag(tag string) (annotation string, optional bool) {
+	values := strings.Split(tag, ",")
+	for _, option := range values[1:] {
+		if strings.TrimSpace(option) == "optional" {
+			optional = true
+		}
+	}
+
+	return strings.TrimSpace(values[0]), optional
+}
@@ -0,0 +1,138 @@
+package dingo
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	fg "github.com/mikelue/go-misc/ioc/frangipani"
+)
+
+// The error of injecting properties to a struct, which contains errors of all failed fields.
+type PropertyInjectionError struct {
+	// Type of the injected struct
+	Type reflect.Type
+	// Errors of failed fields
+	FieldErrors []*fg.FieldBindingError
+}
+func (self *PropertyInjectionError) Error() string {
+	messages := make([]string, 0, len(self.FieldErrors) + 1)
+	messages = append(messages, fmt.Sprintf(
+		"Injecting properties to [%v] has [%d] error(s):",
+		self.Type, len(self.FieldErrors),
+	))
+
+	for _, fieldErr := range self.FieldErrors {
+		messages = append(messages, "\t" + fieldErr.Error())
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+// Injects properties to the fields tagged by "fg" of the struct(must be a pointer).
+//
+//   type server struct {
+//     Port int `fg:"server.port:8080"`
+//     Timeout time.Duration `fg:"server.timeout"`
+//   }
+//
+// The tag is formatted as "<name>[:<default value>]", the values are converted as "frangipani.GetAs()".
+//
+// The property without default value is required, "*PropertyInjectionError" is returned if it is not existing.
+func InjectProperties(env fg.Environment, target interface{}) error {
+	targetValue := reflect.ValueOf(target)
+	if targetValue.Kind() != reflect.Ptr || targetValue.IsNil() || targetValue.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("Target of injecting properties must be a pointer to struct: %T", target)
+	}
+
+	return injectProperties(
+		func() (fg.Environment, error) { return env, nil },
+		targetValue,
+	)
+}
+
+// Whether or not there is any field tagged by "fg"(except "fg:"-"")
+func hasPropertyFields(structType reflect.Type) bool {
+	for i := 0; i < structType.NumField(); i++ {
+		if tag, ok := structType.Field(i).Tag.Lookup(fg.TAG_PROPERTY); ok && tag != "-" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// The environment is only got if there is any field tagged by "fg"
+func injectProperties(envSupplier func() (fg.Environment, error), target reflect.Value) error {
+	structValue := target.Elem()
+	structType := structValue.Type()
+
+	var env fg.Environment
+	fieldErrors := make([]*fg.FieldBindingError, 0)
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+
+		tag, ok := field.Tag.Lookup(fg.TAG_PROPERTY)
+		if !ok || tag == "-" {
+			continue
+		}
+
+		name, defaultValue, hasDefault := parsePropertyTag(tag)
+		fieldPath := structType.Name() + "." + field.Name
+
+		if field.PkgPath != "" {
+			fieldErrors = append(fieldErrors, &fg.FieldBindingError {
+				Property: name, Field: fieldPath,
+				Err: fmt.Errorf("Field is not exported"),
+			})
+			continue
+		}
+
+		if env == nil {
+			var err error
+			if env, err = envSupplier(); err != nil {
+				return err
+			}
+		}
+
+		err := injectProperty(env, name, defaultValue, hasDefault, structValue.Field(i).Addr().Interface())
+		if err != nil {
+			fieldErrors = append(fieldErrors, &fg.FieldBindingError {
+				Property: name, Field: fieldPath, Err: err,
+			})
+		}
+	}
+
+	if len(fieldErrors) > 0 {
+		return &PropertyInjectionError{ target.Type(), fieldErrors }
+	}
+
+	return nil
+}
+
+func injectProperty(env fg.Environment, name string, defaultValue string, hasDefault bool, fieldPointer interface{}) error {
+	err := fg.GetAs(env, name, fieldPointer)
+	if err == nil || !hasDefault || env.ContainsProperty(name) {
+		return err
+	}
+
+	/**
+	 * Converts the default value by the same way of properties
+	 */
+	return fg.GetAs(
+		fg.PropertyResolverBuilder.NewByMap(map[string]interface{} { name: defaultValue }),
+		name, fieldPointer,
+	)
+	// :~)
+}
+
+// Parses the tag as "<name>[:<default value>]"
+func parsePropertyTag(tag string) (name string, defaultValue string, hasDefault bool) {
+	separatorIndex := strings.Index(tag, fg.PLACEHOLDER_VALUE_SEPARATOR)
+	if separatorIndex == -1 {
+		return tag, "", false
+	}
+
+	return tag[:separatorIndex], tag[separatorIndex + len(fg.PLACEHOLDER_VALUE_SEPARATOR):], true
+}
@@ -0,0 +1,138 @@
+package dingo
+
+import (
+	"errors"
+	"net/url"
+	"sync"
+	"time"
+
+	"flamingo.me/dingo"
+
+	fg "github.com/mikelue/go-misc/ioc/frangipani"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Injection of properties", func() {
+	sampleEnv := fg.EnvBuilder.NewByMap(map[string]interface{} {
+		"server.port": 8081,
+		"server.timeout": "3s",
+		"server.hosts": []interface{}{ "10.1.1.1", "10.1.1.2" },
+		"db.url": "pg://10.7.81.33:5432/sugar",
+		"db.pool.size": "x20",
+	})
+
+	Context("AppContext.GetInstance", func() {
+		It("Properties of object and its dependencies", func() {
+			testedServer := newAppContextWithProperties(sampleEnv).
+				GetInstance(new(propertiesServer)).(*propertiesServer)
+
+			Expect(testedServer.Port).To(Equal(8081))
+			Expect(testedServer.Timeout).To(Equal(3 * time.Second))
+			Expect(testedServer.Hosts).To(Equal([]string{ "10.1.1.1", "10.1.1.2" }))
+			Expect(testedServer.MaxConn).To(Equal(64))
+			Expect(testedServer.Skipped).To(BeEmpty())
+			Expect(testedServer.Db.Url.Host).To(Equal("10.7.81.33:5432"))
+		})
+
+		It("Singleton is injected once(concurrent lookups)", func() {
+			injector, _ := dingo.NewInjector()
+			injector.Bind(new(fg.Environment)).ToInstance(sampleEnv)
+			injector.Bind(new(propertiesServer)).In(dingo.Singleton)
+			testedContext := AsAppContext(injector)
+
+			testedServer := testedContext.GetInstance(new(propertiesServer)).(*propertiesServer)
+			testedServer.Port = 9090
+
+			var wg sync.WaitGroup
+			for i := 0; i < 8; i++ {
+				wg.Add(1)
+				go func() {
+					defer GinkgoRecover()
+					defer wg.Done()
+
+					server := testedContext.GetInstance(new(propertiesServer)).(*propertiesServer)
+					Expect(server.Port).To(Equal(9090))
+					Expect(testedServer.Port).To(Equal(9090))
+				}()
+			}
+			wg.Wait()
+		})
+
+		It("Missing required property", func() {
+			testedContext := newAppContextWithProperties(
+				fg.EnvBuilder.NewByMap(map[string]interface{} {}),
+			)
+
+			Expect(func() {
+				testedContext.GetInstance(new(propertiesDb))
+			}).To(PanicWith(MatchError(ContainSubstring("Property[db.url] is not existing"))))
+		})
+	})
+
+	Context("InjectProperties", func() {
+		It("Injected values", func() {
+			testedDb := &propertiesDb{}
+
+			Expect(InjectProperties(sampleEnv, testedDb)).To(Succeed())
+			Expect(testedDb.Url.Path).To(Equal("/sugar"))
+			Expect(testedDb.Pool).To(Equal(8))
+		})
+
+		It("Errors of fields", func() {
+			var testedErr *PropertyInjectionError
+
+			err := InjectProperties(sampleEnv, &struct {
+				Size int `fg:"db.pool.size"`
+				Missing string `fg:"db.missing"`
+				Timeout time.Duration `fg:"server.timeout"`
+			}{})
+
+			Expect(errors.As(err, &testedErr)).To(BeTrue())
+			Expect(testedErr.FieldErrors).To(HaveLen(2))
+			Expect(testedErr.FieldErrors[0].Property).To(Equal("db.pool.size"))
+			Expect(testedErr.FieldErrors[1].Property).To(Equal("db.missing"))
+		})
+
+		It("Not a pointer to struct", func() {
+			Expect(InjectProperties(sampleEnv, propertiesDb{})).NotTo(Succeed())
+		})
+	})
+
+	DescribeTable("parsePropertyTag",
+		func(tag string, expectedName string, expectedDefault string, expectedHasDefault bool) {
+			name, defaultValue, hasDefault := parsePropertyTag(tag)
+
+			Expect(name).To(Equal(expectedName))
+			Expect(defaultValue).To(Equal(expectedDefault))
+			Expect(hasDefault).To(Equal(expectedHasDefault))
+		},
+		Entry("Name only", "server.port", "server.port", "", false),
+		Entry("With default value", "server.port:8080", "server.port", "8080", true),
+		Entry("Empty default value", "server.name:", "server.name", "", true),
+	)
+})
+
+type propertiesDb struct {
+	Url *url.URL `fg:"db.url"`
+	Pool int `fg:"db.pool.max:8"`
+}
+type propertiesServer struct {
+	Port int `fg:"server.port:8080"`
+	Timeout time.Duration `fg:"server.timeout"`
+	Hosts []string `fg:"server.hosts"`
+	MaxConn int `fg:"server.max-conn:64"`
+	Skipped string `fg:"-"`
+	Db *propertiesDb `inject:""`
+}
+
+func newAppContextWithProperties(env fg.Environment) AppContext {
+	injector, err := dingo.NewInjector()
+	if err != nil {
+		panic(err)
+	}
+
+	injector.Bind(new(fg.Environment)).ToInstance(env)
+	return AsAppContext(injector)
+}
@@ -0,0 +1,220 @@
+package dingo
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// The error of resolving an instance, which contains the chain of bindings to the failed one.
+//
+//   Resolving [*app.service -> *app.client -> app.Pool(annotated with "primary")] has error: ...
+type ResolutionError struct {
+	// The bindings from the requested one to the failed one
+	Chain []string
+	// The cause
+	Err error
+}
+func (self *ResolutionError) Error() string {
+	return fmt.Sprintf("Resolving [%s] has error: %v", strings.Join(self.Chain, " -> "), self.Err)
+}
+func (self *ResolutionError) Unwrap() error {
+	return self.Err
+}
+
+// Gets instance of an object/interface as "AppContext.GetInstance()" but returns error rather than panic.
+//
+// The error is "*ResolutionError", which contains the chain of bindings to the failed one.
+func TryGetInstance(ctx AppContext, of interface{}) (interface{}, error) {
+	return TryGetAnnotatedInstance(ctx, of, "")
+}
+
+// Gets instance of an object/interface annotated with the name, see "TryGetInstance()".
+//
+// For the "AppContext" not built by this package, the panic of "AppContext.GetInstance()" is returned as error
+// and the annotated instances are not supported.
+func TryGetAnnotatedInstance(ctx AppContext, of interface{}, annotation string) (obj interface{}, err error) {
+	if impl, ok := ctx.(*appContextImpl); ok {
+		return impl.tryGetAnnotatedInstance(of, annotation)
+	}
+
+	/**
+	 * The "AppContext" implemented outside of this package
+	 */
+	chain := []string{ describeBinding(requestedType(of), annotation) }
+	if annotation != "" {
+		return nil, &ResolutionError {
+			Chain: chain,
+			Err: fmt.Errorf("AppContext[%T] cannot get annotated instances", ctx),
+		}
+	}
+
+	defer func() {
+		p := recover()
+		if p == nil {
+			return
+		}
+
+		panicErr, ok := p.(error)
+		if !ok {
+			panicErr = fmt.Errorf("%v", p)
+		}
+
+		var resolutionErr *ResolutionError
+		if !errors.As(panicErr, &resolutionErr) {
+			panicErr = &ResolutionError{ Chain: chain, Err: panicErr }
+		}
+
+		obj, err = nil, panicErr
+	}()
+
+	return ctx.GetInstance(of), nil
+	// :~)
+}
+
+// Gets the instance of type, e.g., "Get[*server](appContext)" or "Get[Repository](appContext)".
+//
+// The error is "*ResolutionError"(see "TryGetInstance()").
+func Get[T any](ctx AppContext) (T, error) {
+	return GetAnnotated[T](ctx, "")
+}
+
+// Gets the instance of type annotated with the name, e.g., "GetAnnotated[Repository](appContext, "primary")".
+//
+// The error is "*ResolutionError"(see "TryGetAnnotatedInstance()").
+func GetAnnotated[T any](ctx AppContext, name string) (T, error) {
+	var result T
+
+	targetType := reflect.TypeOf(&result).Elem()
+	obj, err := TryGetAnnotatedInstance(ctx, instanceOf(targetType), name)
+	if err != nil {
+		return result, err
+	}
+
+	/**
+	 * The instance of struct is got as pointer
+	 */
+	if typedObj, ok := obj.(T); ok {
+		return typedObj, nil
+	}
+
+	objValue := reflect.ValueOf(obj)
+	if objValue.Kind() == reflect.Ptr && !objValue.IsNil() && objValue.Elem().Type() == targetType {
+		return objValue.Elem().Interface().(T), nil
+	}
+	// :~)
+
+	return result, &ResolutionError {
+		Chain: []string{ describeBinding(targetType, name) },
+		Err: fmt.Errorf("Instance of [%T] is not assignable to [%v]", obj, targetType),
+	}
+}
+
+// Builds the chain of bindings from the requested one to the failed dependency(fields tagged by "inject"),
+// which is found by the types(and annotations) reported by the error of dingo(nothing is instantiated).
+//
+// The chain is best-effort: the fields of interfaces are not followed to their bound implementations,
+// and the requested binding is the only one if the reported types are not found in the fields.
+func diagnose(bindingType reflect.Type, annotation string, err error) []string {
+	chain := findFailedChain(bindingType, annotation, err.Error(), make(map[reflect.Type]bool))
+	if len(chain) == 0 {
+		return []string{ describeBinding(bindingType, annotation) }
+	}
+
+	return chain
+}
+func findFailedChain(bindingType reflect.Type, annotation string, message string, visited map[reflect.Type]bool) []string {
+	current := describeBinding(bindingType, annotation)
+
+	/**
+	 * Only the dependencies of structs reported by the error are followed(as the walker of object graph does)
+	 */
+	structType := bindingType
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+
+	if structType.Kind() == reflect.Struct && !visited[structType] && isReported(message, bindingType, "") {
+		visited[structType] = true
+		defer delete(visited, structType)
+
+		for i := 0; i < structType.NumField(); i++ {
+			field := structType.Field(i)
+
+			tag, ok := field.Tag.Lookup(TAG_INJECT)
+			if !ok || field.PkgPath != "" {
+				continue
+			}
+
+			fieldAnnotation, optional := parseInjectTag(tag)
+			if optional {
+				continue
+			}
+
+			if subChain := findFailedChain(field.Type, fieldAnnotation, message, visited); len(subChain) > 0 {
+				return append([]string{ current }, subChain...)
+			}
+		}
+	}
+	// :~)
+
+	if isReported(message, bindingType, annotation) {
+		return []string{ current }
+	}
+
+	return nil
+}
+
+// Checks whether the type(qualified by package, e.g., "app.Client") and the annotation are reported by the message of error.
+//
+// The names are matched as whole identifiers, e.g., "app.Client" is not matched by "app.HttpClient".
+func isReported(message string, bindingType reflect.Type, annotation string) bool {
+	if bindingType.Kind() == reflect.Ptr {
+		bindingType = bindingType.Elem()
+	}
+
+	if bindingType.Name() == "" || !containsIdentifier(message, bindingType.String()) {
+		return false
+	}
+
+	return annotation == "" || containsIdentifier(message, annotation)
+}
+
+func containsIdentifier(message string, identifier string) bool {
+	return regexp.MustCompile(
+		`(^|[^\w.])` + regexp.QuoteMeta(identifier) + `($|[^\w])`,
+	).MatchString(message)
+}
+
+// The object used to get instance of type from dingo, e.g., "new(server)" for "*server", "new(Repository)" for "Repository"
+func instanceOf(targetType reflect.Type) interface{} {
+	if targetType.Kind() == reflect.Ptr && targetType.Elem().Kind() == reflect.Struct {
+		return reflect.New(targetType.Elem()).Interface()
+	}
+
+	return reflect.New(targetType).Interface()
+}
+
+// Requested type of binding("new(server)" is "*server", "new(Repository)" is "Repository")
+func requestedType(of interface{}) reflect.Type {
+	if ofType, ok := of.(reflect.Type); ok {
+		return ofType
+	}
+
+	ofType := reflect.TypeOf(of)
+	if ofType.Kind() == reflect.Ptr && ofType.Elem().Kind() != reflect.Struct {
+		return ofType.Elem()
+	}
+
+	return ofType
+}
+
+func describeBinding(bindingType reflect.Type, annotation string) string {
+	if annotation == "" {
+		return bindingType.String()
+	}
+
+	return fmt.Sprintf("%v(annotated with %q)", bindingType, annotation)
+}
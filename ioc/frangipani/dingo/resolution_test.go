@@ -0,0 +1,159 @@
+package dingo
+
+import (
+	"errors"
+
+	"flamingo.me/dingo"
+
+	fg "github.com/mikelue/go-misc/ioc/frangipani"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Resolution of instances", func() {
+	var testedContext AppContext
+	var providedServices int
+
+	BeforeEach(func() {
+		providedServices = 0
+
+		injector, _ := dingo.NewInjector()
+		injector.Bind(new(fg.Environment)).ToInstance(fg.EnvBuilder.NewByMap(map[string]interface{} {}))
+		injector.Bind(new(repository)).To(new(memoryRepository))
+		injector.Bind(new(repository)).AnnotatedWith("legacy").To(new(gormRepository))
+		injector.Bind(new(resolutionService)).ToProvider(func(repository repository) *resolutionService {
+			providedServices++
+			return &resolutionService{ Repository: repository, Legacy: &gormRepository{} }
+		})
+
+		testedContext = AsAppContext(injector)
+	})
+
+	Context("Get", func() {
+		It("Pointer to struct", func() {
+			testedService, err := Get[*resolutionService](testedContext)
+
+			Expect(err).To(Succeed())
+			Expect(testedService.Repository.name()).To(Equal("memory"))
+			Expect(testedService.Legacy.name()).To(Equal("gorm"))
+		})
+
+		It("Interface", func() {
+			testedRepository, err := Get[repository](testedContext)
+
+			Expect(err).To(Succeed())
+			Expect(testedRepository.name()).To(Equal("memory"))
+		})
+
+		It("Missing binding(names of types are overlapped)", func() {
+			_, err := Get[*resolutionOverlappedService](testedContext)
+
+			var testedErr *ResolutionError
+			Expect(errors.As(err, &testedErr)).To(BeTrue())
+			Expect(testedErr.Chain).To(Equal([]string{
+				"*dingo.resolutionOverlappedService", "*dingo.unresolvedClient", `dingo.repository(annotated with "missing")`,
+			}))
+		})
+
+		It("Missing binding(with chain)", func() {
+			_, err := Get[*resolutionBrokenService](testedContext)
+
+			var testedErr *ResolutionError
+			Expect(errors.As(err, &testedErr)).To(BeTrue())
+			Expect(testedErr.Chain).To(Equal([]string{
+				"*dingo.resolutionBrokenService", "*dingo.resolutionBrokenDependency", `dingo.repository(annotated with "missing")`,
+			}))
+			Expect(err).To(MatchError(ContainSubstring(
+				`Resolving [*dingo.resolutionBrokenService -> *dingo.resolutionBrokenDependency -> dingo.repository(annotated with "missing")]`,
+			)))
+			Expect(providedServices).To(BeNumerically("<=", 1), "Nothing is instantiated by building the chain")
+		})
+	})
+
+	Context("AppContext implemented outside of this package", func() {
+		var externalContext AppContext
+
+		BeforeEach(func() {
+			externalContext = &sampleExternalContext{ testedContext }
+		})
+
+		It("Instance", func() {
+			testedRepository, err := Get[repository](externalContext)
+
+			Expect(err).To(Succeed())
+			Expect(testedRepository.name()).To(Equal("memory"))
+		})
+
+		It("Panic is returned as error", func() {
+			_, err := TryGetInstance(externalContext, new(resolutionBrokenService))
+
+			var testedErr *ResolutionError
+			Expect(errors.As(err, &testedErr)).To(BeTrue())
+			Expect(testedErr.Chain[0]).To(Equal("*dingo.resolutionBrokenService"))
+		})
+
+		It("Annotated instance is not supported", func() {
+			_, err := GetAnnotated[repository](externalContext, "legacy")
+			Expect(err).To(MatchError(ContainSubstring("cannot get annotated instances")))
+		})
+	})
+
+	DescribeTable("GetAnnotated",
+		func(name string, expected string) {
+			testedRepository, err := GetAnnotated[repository](testedContext, name)
+
+			Expect(err).To(Succeed())
+			Expect(testedRepository.name()).To(Equal(expected))
+		},
+		Entry("Not annotated", "", "memory"),
+		Entry("Annotated", "legacy", "gorm"),
+	)
+
+	It("TryGetInstance(error of properties)", func() {
+		_, err := TryGetInstance(testedContext, new(resolutionDbService))
+
+		Expect(err).To(MatchError(ContainSubstring(
+			"Resolving [*dingo.resolutionDbService -> *dingo.resolutionDbClient]",
+		)))
+		Expect(err).To(MatchError(ContainSubstring("db.url")))
+	})
+})
+
+// Wraps "AppContext" as the one implemented outside of this package
+type sampleExternalContext struct {
+	AppContext
+}
+
+type resolutionService struct {
+	Repository repository `inject:""`
+	Legacy repository `inject:"legacy"`
+	Optional repository `inject:"optional-one,optional"`
+}
+
+type resolutionBrokenService struct {
+	Service *resolutionService `inject:""`
+	Broken *resolutionBrokenDependency `inject:""`
+}
+type resolutionBrokenDependency struct {
+	Repository repository `inject:"missing"`
+}
+
+// The name of "resolvedClient" is a part of "unresolvedClient"
+type resolutionOverlappedService struct {
+	Resolved *resolvedClient `inject:""`
+	Unresolved *unresolvedClient `inject:""`
+}
+type resolvedClient struct {
+	Repository repository `inject:""`
+}
+type unresolvedClient struct {
+	Repository repository `inject:"missing"`
+}
+
+type resolutionDbService struct {
+	Client *resolutionDbClient `inject:""`
+}
+type resolutionDbClient struct {
+	Url string `fg:"db.url"`
+}
@@ -5,6 +5,8 @@ An environment a container of properties and profile for your application.
 
 You can construct "Environment" by "EnvBuilder.NewByMap" or "EnvBuilder.NewByViper".
 
+The "EnvBuilder.NewOverlay" derives new environment from existing one with overriding properties(e.g., for testing).
+
 Loading properties
 
 See README: https://github.com/mikelue/go-misc/blob/master/ioc/frangipani/README.md
@@ -15,12 +17,20 @@ package frangipani
 
 import (
 	"strings"
+
+	"github.com/spf13/cast"
 	"github.com/spf13/viper"
 )
 
 // Property name for "fgapp.profiles.active"
 const PROP_ACITVE_PROFILES = "fgapp.profiles.active"
 
+// Property name for "fgapp.profiles.include", which are appended after active profiles
+const PROP_PROFILES_INCLUDE = "fgapp.profiles.include"
+
+// Prefix of property name for "fgapp.profiles.group.<profile>", which are activated with the profile
+const PROP_PROFILES_GROUP = "fgapp.profiles.group"
+
 // "default" value of profile
 const DEFAULT_PROFILE = "default"
 
@@ -47,6 +57,67 @@ func (IEnvBuilder) NewByMap(props map[string]interface{}) Environment {
 	return newEnv
 }
 
+// Constructs new environment by overriding properties of the base one(which is not changed),
+// the "profiles" are appended to the active profiles("fgapp.profiles.active").
+//
+// The placeholders of the base environment are resolved by the overriding properties, e.g.,
+// "db.url: pg://${db.host}" of base is resolved to "pg://10.1.1.1" if "db.host: 10.1.1.1" is overridden.
+//
+// The overriding properties are matched by relaxed names(see "CanonicalName"),
+// e.g., "server.httpPort" overrides "server.http-port" of base environment.
+//
+// The values of "random.*" are shared with the base environment, e.g., "${random.port}" gives the same port.
+//
+// If the base environment cannot list its properties(see "PropertiesLister"),
+// the properties not overridden are looked up by the base one(whose placeholders are resolved by itself),
+// and only the overriding properties are listed by the new environment.
+func (self IEnvBuilder) NewOverlay(base Environment, overrides map[string]interface{}, profiles ...string) Environment {
+	props, listed := AllProperties(base)
+
+	/**
+	 * Removes the properties of base which are overridden by relaxed names
+	 */
+	if listed {
+		overriddenNames := make(map[string]bool, len(overrides))
+		for name := range overrides {
+			overriddenNames[relaxedName(name)] = true
+		}
+		for name := range props {
+			if overriddenNames[relaxedName(name)] {
+				delete(props, name)
+			}
+		}
+	} else {
+		props = make(map[string]interface{}, len(overrides) + 1)
+	}
+	// :~)
+
+	for name, value := range overrides {
+		props[name] = value
+	}
+
+	/**
+	 * The properties not existing in the overlay(e.g., "random.*") are looked up by the base environment
+	 */
+	newResolver := func() mapBasedPropertyResolver {
+		resolver := newMapBasedPropertyResolver(props)
+		resolver.fallback = base
+		return resolver
+	}
+	// :~)
+
+	if len(profiles) > 0 {
+		propsEnv := &mapBasedEnv{ PropertyResolver: newResolver() }
+		props[PROP_ACITVE_PROFILES] = strings.Join(
+			append(propsEnv.getProfilesProperty(PROP_ACITVE_PROFILES), profiles...), ",",
+		)
+	}
+
+	newEnv := &mapBasedEnv{ PropertyResolver: newResolver() }
+	newEnv.activeProfiles = newEnv.processActiveProfiles()
+	return newEnv
+}
+
 // A conceptual container to gain properties and profiles of a application.
 type Environment interface {
 	// Getter of properties
@@ -56,11 +127,58 @@ type Environment interface {
 	AcceptsProfiles(profiles Profiles) bool
 	// Gets current active profiles.
 	//
+	// The profiles are ordered as:
+	//   1. Profiles of "fgapp.profiles.active"
+	//   2. Profiles of "fgapp.profiles.include"
+	//   3. The "default" profile
+	//
+	// Every profile is followed by members of its group("fgapp.profiles.group.<profile>"),
+	// and the duplicated profiles are removed.
+	//
 	// Whether or not there is viable "fgapp.profiles.active" property,
 	// the "default" profile will always be appended if it is not existing in the property.
 	GetActiveProfiles() []string
 }
 
+// Implemented by environments(or resolvers) which could list their properties,
+// the ones built by "EnvBuilder"(and "PropertyResolverBuilder") implement this interface.
+//
+// See "AllProperties()", "BindProperties()", and "GetAs()"
+type PropertiesLister interface {
+	// Gets a copy of all of the properties(placeholders are not resolved).
+	AllProperties() map[string]interface{}
+}
+
+// Gets a copy of all of the properties(placeholders are not resolved).
+//
+// The "bool" is false if the resolver cannot list its properties(see "PropertiesLister").
+func AllProperties(resolver PropertyResolver) (map[string]interface{}, bool) {
+	mapBasedResolver, ok := asMapBasedResolver(resolver)
+	if !ok {
+		return nil, false
+	}
+
+	return mapBasedResolver.AllProperties(), true
+}
+
+// Gets the resolver based on map, the properties are listed by "PropertiesLister" if the resolver is not built by this package.
+func asMapBasedResolver(resolver PropertyResolver) (mapBasedPropertyResolver, bool) {
+	switch typedResolver := resolver.(type) {
+	case mapBasedPropertyResolver:
+		return typedResolver, true
+	case *mapBasedEnv:
+		if mapBasedResolver, ok := typedResolver.PropertyResolver.(mapBasedPropertyResolver); ok {
+			return mapBasedResolver, true
+		}
+	}
+
+	if lister, ok := resolver.(PropertiesLister); ok {
+		return newMapBasedPropertyResolver(lister.AllProperties()), true
+	}
+
+	return mapBasedPropertyResolver{}, false
+}
+
 func init() {
 	EnvBuilder = 0
 }
@@ -78,6 +196,10 @@ func (self *mapBasedEnv) GetActiveProfiles() []string {
 	copy(profiles, self.activeProfiles)
 	return profiles
 }
+func (self *mapBasedEnv) AllProperties() map[string]interface{} {
+	props, _ := AllProperties(self.PropertyResolver)
+	return props
+}
 func (self *mapBasedEnv) processActiveProfiles() []string {
 	uniqueProfiles := &uniqueStringSlice {
 		values: []string{},
@@ -85,14 +207,13 @@ func (self *mapBasedEnv) processActiveProfiles() []string {
 	}
 
 	/**
-	 * Adds effective profiles
+	 * Adds effective and included profiles
 	 */
-	profilesByProperty := strings.Split(self.GetProperty(PROP_ACITVE_PROFILES), ",")
-	for _, profile := range profilesByProperty {
-		profile = strings.TrimSpace(profile)
-		if profile != "" {
-			uniqueProfiles.add(profile)
-		}
+	for _, profile := range self.getProfilesProperty(PROP_ACITVE_PROFILES) {
+		self.addProfileWithGroup(uniqueProfiles, profile)
+	}
+	for _, profile := range self.getProfilesProperty(PROP_PROFILES_INCLUDE) {
+		self.addProfileWithGroup(uniqueProfiles, profile)
 	}
 	// :~)
 
@@ -100,12 +221,45 @@ func (self *mapBasedEnv) processActiveProfiles() []string {
 	 * Adds default profile
 	 */
 	if !uniqueProfiles.has(DEFAULT_PROFILE) {
-		uniqueProfiles.add(DEFAULT_PROFILE)
+		self.addProfileWithGroup(uniqueProfiles, DEFAULT_PROFILE)
 	}
 	// :~)
 
 	return uniqueProfiles.values
 }
+// Adds the profile and members of its group(recursively)
+func (self *mapBasedEnv) addProfileWithGroup(uniqueProfiles *uniqueStringSlice, profile string) {
+	if uniqueProfiles.has(profile) {
+		return
+	}
+
+	uniqueProfiles.add(profile)
+
+	for _, member := range self.getProfilesProperty(PROP_PROFILES_GROUP + "." + profile) {
+		self.addProfileWithGroup(uniqueProfiles, member)
+	}
+}
+// Gets profiles by property, which could be a string(separated by comma) or a slice
+func (self *mapBasedEnv) getProfilesProperty(name string) []string {
+	value := self.Typed().Get(name)
+
+	var values []string
+	if text, ok := value.(string); ok {
+		values = strings.Split(text, ",")
+	} else {
+		values = cast.ToStringSlice(value)
+	}
+
+	profiles := make([]string, 0, len(values))
+	for _, profile := range values {
+		profile = strings.TrimSpace(profile)
+		if profile != "" {
+			profiles = append(profiles, profile)
+		}
+	}
+
+	return profiles
+}
 func (self *mapBasedEnv) matchProfile(profile string) bool {
 	for _, activeProfile := range self.activeProfiles {
 		if activeProfile == profile {
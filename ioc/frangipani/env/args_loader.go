@@ -11,6 +11,16 @@ const (
 	FLAG_CONFIG_JSON = ".config.json"
 	// Properties packed as YAML format
 	FLAG_CONFIG_YAML = ".config.yaml"
+	// Properties packed as TOML format
+	FLAG_CONFIG_TOML = ".config.toml"
+	// Properties packed as HCL format
+	FLAG_CONFIG_HCL = ".config.hcl"
+	// Properties packed as format of Java properties
+	FLAG_CONFIG_PROPERTIES = ".config.properties"
+	// Properties packed as dotenv format("KEY=value" per line)
+	FLAG_CONFIG_DOTENV = ".config.dotenv"
+	// Properties packed as INI format
+	FLAG_CONFIG_INI = ".config.ini"
 	// Properties from external file
 	FLAG_CONFIG_FILES = PROP_CONFIG_FILES
 	// For activated profiles(see frangipani)
@@ -40,6 +50,26 @@ func (self *argsConfig) bindByPflag(flagSet *pflag.FlagSet) *packedConfig {
 		self.prefixWith(FLAG_CONFIG_YAML), "",
 		`'{ key: value }'`,
 	)
+	flagSet.StringVar(&packedConfigByArgs.tomlProps,
+		self.prefixWith(FLAG_CONFIG_TOML), "",
+		`'key = value'`,
+	)
+	flagSet.StringVar(&packedConfigByArgs.hclProps,
+		self.prefixWith(FLAG_CONFIG_HCL), "",
+		`'key = value'`,
+	)
+	flagSet.StringVar(&packedConfigByArgs.propertiesProps,
+		self.prefixWith(FLAG_CONFIG_PROPERTIES), "",
+		`'key=value'`,
+	)
+	flagSet.StringVar(&packedConfigByArgs.dotenvProps,
+		self.prefixWith(FLAG_CONFIG_DOTENV), "",
+		`'KEY=value'`,
+	)
+	flagSet.StringVar(&packedConfigByArgs.iniProps,
+		self.prefixWith(FLAG_CONFIG_INI), "",
+		`'[section] key=value'`,
+	)
 	flagSet.StringVar(&packedConfigByArgs.externalFiles,
 		self.prefixWith(FLAG_CONFIG_FILES), "",
 		`'<file path>'`,
@@ -51,6 +81,17 @@ func (self *argsConfig) bindByPflag(flagSet *pflag.FlagSet) *packedConfig {
 	// :~)
 
 	packedConfigByArgs.prefix = self.ordinaryPrefix
+	packedConfigByArgs.names = packedNames {
+		json: "--" + self.prefixWith(FLAG_CONFIG_JSON),
+		yaml: "--" + self.prefixWith(FLAG_CONFIG_YAML),
+		toml: "--" + self.prefixWith(FLAG_CONFIG_TOML),
+		hcl: "--" + self.prefixWith(FLAG_CONFIG_HCL),
+		properties: "--" + self.prefixWith(FLAG_CONFIG_PROPERTIES),
+		dotenv: "--" + self.prefixWith(FLAG_CONFIG_DOTENV),
+		ini: "--" + self.prefixWith(FLAG_CONFIG_INI),
+		files: "--" + self.prefixWith(FLAG_CONFIG_FILES),
+		profiles: "--" + self.prefixWith(FLAG_ACITVE_PROFILES),
+	}
 	return packedConfigByArgs
 }
 func (self *argsConfig) prefixWith(suffix string) string {
@@ -39,6 +39,11 @@ var contextOfBindByPflag = func() {
 			[]string {
 				`--myapp.config.json={ "apple.size": 98 }`,
 				`--myapp.config.yaml={ "tamarind.size": 62 }`,
+				`--myapp.config.properties=papaya.size=45`,
+				`--myapp.config.hcl="mango.size" = 37`,
+				"--myapp.config.ini=[kiwi]\nsize=24",
+				"--myapp.config.toml=[lychee]\nsize = 81",
+				`--myapp.config.dotenv=DURIAN_SIZE=19`,
 				`--myapp.config.files=a1.yaml,a2.yaml`,
 				`--myapp.profiles.active=g1,g2`,
 			},
@@ -49,7 +54,7 @@ var contextOfBindByPflag = func() {
 	})
 
 	Context("bindByPflag", func() {
-		Context("JSON/YAML as properties", func() {
+		Context("Formatted content as properties", func() {
 			var testedEnv fg.Environment
 
 			BeforeEach(func() {
@@ -65,6 +70,31 @@ var contextOfBindByPflag = func() {
 				Expect(testedEnv.Typed().GetInt("apple.size")).
 					To(BeEquivalentTo(98))
 			})
+
+			It("properties", func() {
+				Expect(testedEnv.Typed().GetInt("papaya.size")).
+					To(BeEquivalentTo(45))
+			})
+
+			It("hcl", func() {
+				Expect(testedEnv.Typed().GetInt("mango.size")).
+					To(BeEquivalentTo(37))
+			})
+
+			It("ini", func() {
+				Expect(testedEnv.Typed().GetInt("kiwi.size")).
+					To(BeEquivalentTo(24))
+			})
+
+			It("toml", func() {
+				Expect(testedEnv.Typed().GetInt("lychee.size")).
+					To(BeEquivalentTo(81))
+			})
+
+			It("dotenv", func() {
+				Expect(testedEnv.Typed().GetInt("durian.size")).
+					To(BeEquivalentTo(19))
+			})
 		})
 
 		It("file name", func() {
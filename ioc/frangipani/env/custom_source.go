@@ -0,0 +1,221 @@
+package env
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/spf13/viper"
+)
+
+// The first value of "ConfigSource" for customized sources
+const CL_CUSTOM_BASE ConfigSource = 100
+
+// Customized source of properties(e.g., embedded files or remote server),
+// which could be registered by "RegisterSource()".
+type CustomSource interface {
+	// Loads properties without profiles(higher priority first)
+	Load() ([]*viper.Viper, error)
+	// Loads properties of active profiles(higher priority first)
+	//
+	// The returned vipers would be put in front of the ones loaded by "Load()".
+	LoadProfiles(profiles ...string) ([]*viper.Viper, error)
+}
+
+// Registers a customized source, the returned "ConfigSource" could be used by "ConfigBuilder.Priority()".
+//
+//   var CL_EMBED = env.RegisterSource("embed", env.SourceBuilder.NewByFS(embeddedFiles, "fgapp"))
+//
+//   NewConfigBuilder().
+//     Priority(CL_ARGS, CL_ENVVAR, CL_PWD, CL_EMBED)
+//
+// The name is used as location of properties(see "PropertyOrigin").
+func RegisterSource(name string, source CustomSource) ConfigSource {
+	customSources.Lock()
+	defer customSources.Unlock()
+
+	newSource := CL_CUSTOM_BASE + ConfigSource(len(customSources.sources))
+	customSources.sources = append(customSources.sources, &registeredSource{ name, source })
+
+	return newSource
+}
+
+// Method space for building of built-in "CustomSource"
+var SourceBuilder ISourceBuilder
+
+type ISourceBuilder int
+// Loads files of default names(e.g., "<prefix>-config.yaml", "<prefix>-config-<profile>.yaml") from "fs.FS".
+//
+// This source is useful for default configurations by "embed.FS":
+//
+//   //go:embed fgapp-config*.yaml
+//   var defaultConfig embed.FS
+//
+//   env.SourceBuilder.NewByFS(defaultConfig, "fgapp")
+func (*ISourceBuilder) NewByFS(fsys fs.FS, prefix string) CustomSource {
+	return &fsSource{ fsys, prefix }
+}
+// Loads a directory of mounted files(e.g., ConfigMap of Kubernetes), which the name of file is the key of property,
+// and the content of file is the value of property.
+//
+// Hidden files(name starts with ".") are ignored.
+func (*ISourceBuilder) NewByKeyPerFile(dir string) CustomSource {
+	return &keyPerFileSource{ dir }
+}
+
+var customSources = &struct {
+	sync.RWMutex
+	sources []*registeredSource
+}{}
+
+type registeredSource struct {
+	name string
+	source CustomSource
+}
+
+func getCustomSource(source ConfigSource) (*registeredSource, bool) {
+	customSources.RLock()
+	defer customSources.RUnlock()
+
+	index := int(source - CL_CUSTOM_BASE)
+	if index < 0 || index >= len(customSources.sources) {
+		return nil, false
+	}
+
+	return customSources.sources[index], true
+}
+
+func newCustomWorker(registered *registeredSource) *customWorker {
+	return &customWorker{ registeredSource: registered }
+}
+
+// Adapts "CustomSource" to "loadingWorker", errors of loading are logged.
+type customWorker struct {
+	*registeredSource
+	// Cached vipers
+	loadedVipers vipers
+}
+func (self *customWorker) load() vipers {
+	if self.loadedVipers != nil {
+		return self.loadedVipers
+	}
+
+	loadedVipers, err := self.source.Load()
+	if err != nil {
+		configLogger.Warnf("Load source[%s] has error: %v", self.name, err)
+	}
+
+	self.loadedVipers = append(make(vipers, 0, len(loadedVipers)), loadedVipers...)
+	configLogger.Debugf("Source[%s] has [%d] vipers.", self.name, len(self.loadedVipers))
+	return self.loadedVipers
+}
+func (self *customWorker) loadWithProfiles(profiles ...string) vipers {
+	loadedVipers := self.load()
+
+	if len(profiles) == 0 {
+		return loadedVipers
+	}
+
+	vipersOfProfiles, err := self.source.LoadProfiles(profiles...)
+	if err != nil {
+		configLogger.Warnf("Load source[%s] with profiles has error: %v", self.name, err)
+	}
+
+	return append(make(vipers, 0), vipersOfProfiles...).
+		appendMore(loadedVipers...)
+}
+func (self *customWorker) locationOf(*viper.Viper) string {
+	return self.name
+}
+
+type fsSource struct {
+	fsys fs.FS
+	prefix string
+}
+func (self *fsSource) Load() ([]*viper.Viper, error) {
+	return self.loadFiles(newDefaultFilesWorker(self.prefix).files)
+}
+func (self *fsSource) LoadProfiles(profiles ...string) ([]*viper.Viper, error) {
+	fileNames := make([]string, 0)
+	for _, fileName := range newDefaultFilesWorker(self.prefix).files {
+		fileNames = append(fileNames, profiledFiles(fileName, profiles...)...)
+	}
+
+	return self.loadFiles(fileNames)
+}
+func (self *fsSource) loadFiles(fileNames []string) ([]*viper.Viper, error) {
+	loadedVipers := make([]*viper.Viper, 0)
+
+	for _, fileName := range fileNames {
+		content, err := fs.ReadFile(self.fsys, fileName)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return loadedVipers, err
+		}
+
+		viperObj := viper.New()
+		viperObj.SetConfigType(strings.TrimPrefix(path.Ext(fileName), "."))
+		if err := viperObj.ReadConfig(bytes.NewReader(content)); err != nil {
+			return loadedVipers, fmt.Errorf("Read file[%s] has error: %w", fileName, err)
+		}
+
+		configLogger.Infof("Config file loaded(by fs.FS): [%s]", fileName)
+		loadedVipers = append(loadedVipers, viperObj)
+	}
+
+	return loadedVipers, nil
+}
+
+type keyPerFileSource struct {
+	dir string
+}
+func (self *keyPerFileSource) Load() ([]*viper.Viper, error) {
+	entries, err := ioutil.ReadDir(self.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	viperObj := viper.New()
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		/**
+		 * Uses "os.Stat" so that symbolic links(used by mounted volumes) are followed
+		 */
+		filename := filepath.Join(self.dir, entry.Name())
+		stat, err := os.Stat(filename)
+		if err != nil {
+			return nil, err
+		}
+		if stat.IsDir() {
+			continue
+		}
+		// :~)
+
+		content, err := ioutil.ReadFile(filename)
+		if err != nil {
+			return nil, err
+		}
+
+		viperObj.Set(entry.Name(), strings.TrimRight(string(content), "\r\n"))
+	}
+
+	return []*viper.Viper{ viperObj }, nil
+}
+func (self *keyPerFileSource) LoadProfiles(...string) ([]*viper.Viper, error) {
+	return nil, nil
+}
@@ -0,0 +1,130 @@
+package env
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing/fstest"
+
+	"github.com/mikelue/go-misc/utils"
+	"github.com/spf13/pflag"
+
+	fg "github.com/mikelue/go-misc/ioc/frangipani"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var sampleFS = fstest.MapFS {
+	"pear-config.yaml": { Data: []byte("db.host: 10.51.0.1\ndb.port: 5432\n") },
+	"pear-config-p1.yaml": { Data: []byte("db.host: 10.51.0.2\n") },
+	"pear-config.toml": { Data: []byte("[db]\nuser = \"pear\"\n") },
+}
+
+// Sources are registered globally, so the sample one is registered only once for the suite
+var pearSource ConfigSource
+var _ = BeforeSuite(func() {
+	pearSource = RegisterSource("embedded-pear", SourceBuilder.NewByFS(sampleFS, "pear"))
+})
+
+var _ = Describe("Customized sources", func() {
+
+	Context("Registered source", func() {
+		var oldOsArgs []string
+
+		BeforeEach(func() {
+			oldOsArgs = os.Args
+			os.Args = []string {
+				`--pear.profiles.active=p1`,
+				`--pear.config.yaml={ db.port: 5433 }`,
+			}
+		})
+		AfterEach(func() {
+			os.Args = oldOsArgs
+		})
+
+		It("Loads properties by priority and profiles", func() {
+			testedEnv := NewConfigBuilder().
+				Prefix("pear").
+				Priority(CL_ARGS, pearSource).
+				Pflags(pflag.NewFlagSet("test-custom-source", pflag.ExitOnError)).
+				Build().
+				ParseFlags().
+				Load().(TrackedEnvironment)
+
+			Expect(testedEnv.GetProperty("db.host")).To(Equal("10.51.0.2"))
+			Expect(testedEnv.Typed().GetInt("db.port")).To(Equal(5433))
+			Expect(testedEnv.GetProperty("db.user")).To(Equal("pear"))
+
+			origins := testedEnv.GetOrigins("db.host")
+			Expect(origins).To(HaveLen(2))
+			Expect(origins[0].String()).To(Equal("embedded-pear"))
+			Expect(pearSource.String()).To(Equal("embedded-pear"))
+		})
+	})
+
+	Context("NewByFS", func() {
+		It("Without profiles", func() {
+			testedVipers, err := SourceBuilder.NewByFS(sampleFS, "pear").Load()
+
+			Expect(err).To(Succeed())
+			Expect(testedVipers).To(HaveLen(2))
+			Expect(testedVipers[0].GetString("db.host")).To(Equal("10.51.0.1"))
+		})
+
+		It("Malformed file", func() {
+			malformedFS := fstest.MapFS {
+				"pear-config.yaml": { Data: []byte("db.host: [10.51.0.1\n") },
+			}
+
+			_, err := SourceBuilder.NewByFS(malformedFS, "pear").Load()
+			Expect(err).To(MatchError(MatchRegexp(`pear-config.yaml`)))
+		})
+	})
+
+	Context("NewByKeyPerFile", func() {
+		tmpDir := utils.RollbackContainerBuilder.
+			NewTmpDir("fake-k8s-config-*")
+		var dirParams utils.Params
+
+		BeforeEach(func() {
+			dirParams, _ = tmpDir.Setup()
+			dir := dirParams[utils.PKEY_TEMP_DIR].(string)
+
+			sampleFiles := map[string]string {
+				"db.host": "10.52.0.1\n",
+				"db.password": "cP8Xu2Ka",
+				".hidden": "hidden-value",
+			}
+			for name, content := range sampleFiles {
+				filename := fmt.Sprintf("%s/%s", dir, name)
+				if err := ioutil.WriteFile(filename, []byte(content), 0644); err != nil {
+					GinkgoT().Errorf("Unable to write file[%s]: %v", filename, err)
+				}
+			}
+			os.Mkdir(fmt.Sprintf("%s/..data", dir), 0755)
+		})
+		AfterEach(func() {
+			tmpDir.TearDown(dirParams)
+		})
+
+		It("Every file as a property", func() {
+			dir := dirParams[utils.PKEY_TEMP_DIR].(string)
+			testedVipers, err := SourceBuilder.NewByKeyPerFile(dir).Load()
+
+			Expect(err).To(Succeed())
+
+			testedEnv := fg.EnvBuilder.NewByVipers(testedVipers...)
+			Expect(testedEnv.GetProperty("db.host")).To(Equal("10.52.0.1"))
+			Expect(testedEnv.GetProperty("db.password")).To(Equal("cP8Xu2Ka"))
+			Expect(testedEnv.ContainsProperty(".hidden")).To(BeFalse())
+		})
+
+		It("Not existing directory", func() {
+			testedVipers, err := SourceBuilder.NewByKeyPerFile("/not-existing/config").Load()
+
+			Expect(err).To(Succeed())
+			Expect(testedVipers).To(BeEmpty())
+		})
+	})
+})
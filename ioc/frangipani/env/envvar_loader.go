@@ -12,6 +12,16 @@ const (
 	ENVVAR_JSON = "_CONFIG_JSON"
 	// Name of environment variable for packed properties as YAML format
 	ENVVAR_YAML = "_CONFIG_YAML"
+	// Name of environment variable for packed properties as TOML format
+	ENVVAR_TOML = "_CONFIG_TOML"
+	// Name of environment variable for packed properties as HCL format
+	ENVVAR_HCL = "_CONFIG_HCL"
+	// Name of environment variable for packed properties as format of Java properties
+	ENVVAR_PROPERTIES = "_CONFIG_PROPERTIES"
+	// Name of environment variable for packed properties as dotenv format
+	ENVVAR_DOTENV = "_CONFIG_DOTENV"
+	// Name of environment variable for packed properties as INI format
+	ENVVAR_INI = "_CONFIG_INI"
 	// Name of environment variable for external file
 	ENVVAR_FILE = "_CONFIG_FILES"
 	// Name of environment variable for activated profiles(see frangipani)
@@ -37,6 +47,11 @@ func (self *envConfig) load() *packedConfig {
 
 	self.bind(envViper, ENVVAR_JSON)
 	self.bind(envViper, ENVVAR_YAML)
+	self.bind(envViper, ENVVAR_TOML)
+	self.bind(envViper, ENVVAR_HCL)
+	self.bind(envViper, ENVVAR_PROPERTIES)
+	self.bind(envViper, ENVVAR_DOTENV)
+	self.bind(envViper, ENVVAR_INI)
 	self.bind(envViper, ENVVAR_FILE)
 	self.bind(envViper, ENVVAR_PROFILES_ACTIVE)
 
@@ -44,8 +59,25 @@ func (self *envConfig) load() *packedConfig {
 	packedConfigByEnv.prefix = self.ordinaryPrefix
 	packedConfigByEnv.jsonProps = self.getBySuffix(envViper, ENVVAR_JSON)
 	packedConfigByEnv.yamlProps = self.getBySuffix(envViper, ENVVAR_YAML)
+	packedConfigByEnv.tomlProps = self.getBySuffix(envViper, ENVVAR_TOML)
+	packedConfigByEnv.hclProps = self.getBySuffix(envViper, ENVVAR_HCL)
+	packedConfigByEnv.propertiesProps = self.getBySuffix(envViper, ENVVAR_PROPERTIES)
+	packedConfigByEnv.dotenvProps = self.getBySuffix(envViper, ENVVAR_DOTENV)
+	packedConfigByEnv.iniProps = self.getBySuffix(envViper, ENVVAR_INI)
 	packedConfigByEnv.externalFiles = self.getBySuffix(envViper, ENVVAR_FILE)
 	packedConfigByEnv.activeProfiles = self.getBySuffix(envViper, ENVVAR_PROFILES_ACTIVE)
+	packedConfigByEnv.overrides = envVarOverrides()
+	packedConfigByEnv.names = packedNames {
+		json: "$" + self.prefixWith(ENVVAR_JSON),
+		yaml: "$" + self.prefixWith(ENVVAR_YAML),
+		toml: "$" + self.prefixWith(ENVVAR_TOML),
+		hcl: "$" + self.prefixWith(ENVVAR_HCL),
+		properties: "$" + self.prefixWith(ENVVAR_PROPERTIES),
+		dotenv: "$" + self.prefixWith(ENVVAR_DOTENV),
+		ini: "$" + self.prefixWith(ENVVAR_INI),
+		files: "$" + self.prefixWith(ENVVAR_FILE),
+		profiles: "$" + self.prefixWith(ENVVAR_PROFILES_ACTIVE),
+	}
 
 	return packedConfigByEnv
 }
@@ -30,6 +30,8 @@ var contextOfLoadByEnv = func() {
 		map[string]string {
 			"KKAPP_CONFIG_YAML": `{ srv.lion.port: 981 }`,
 			"KKAPP_CONFIG_JSON": `{ "srv.deer.port": 651 }`,
+			"KKAPP_CONFIG_TOML": "[srv.fox]\nport = 328",
+			"KKAPP_CONFIG_DOTENV": "SRV_OWL_PORT=417",
 			"KKAPP_CONFIG_FILES": "o-sample-9.json,o-sample-10.json",
 			"KKAPP_PROFILES_ACTIVE": "h1,h2",
 		},
@@ -46,7 +48,7 @@ var contextOfLoadByEnv = func() {
 		envContainer.TearDown()
 	})
 
-	Context("JSON/YAML as properties", func() {
+	Context("Formatted content as properties", func() {
 		var testedEnv fg.Environment
 
 		BeforeEach(func() {
@@ -62,6 +64,16 @@ var contextOfLoadByEnv = func() {
 			Expect(testedEnv.Typed().GetInt("srv.deer.port")).
 				To(BeEquivalentTo(651))
 		})
+
+		It("toml", func() {
+			Expect(testedEnv.Typed().GetInt("srv.fox.port")).
+				To(BeEquivalentTo(328))
+		})
+
+		It("dotenv", func() {
+			Expect(testedEnv.Typed().GetInt("srv.owl.port")).
+				To(BeEquivalentTo(417))
+		})
 	})
 
 	It("file name", func() {
@@ -102,29 +102,40 @@ func profiledFiles(fileName string, profiles ...string) []string {
 
 // Initializes viper by external file
 func readInByFile(file string, warnNotFound bool) (*viper.Viper, bool) {
-	_, err := os.Stat(file)
+	viperByFile, err := readInByFileE(file)
 	if err != nil {
-		if os.IsNotExist(err) {
-			if warnNotFound {
-				configLogger.Warnf("file could not be found: [%s]", file)
-			} else {
-				configLogger.Debugf("file could not be found: [%s]", file)
-			}
-		} else {
-			configLogger.Warn(fmt.Errorf("Stat file has error: %w", err))
-		}
-
+		logFileError(file, err, warnNotFound)
 		return nil, false
 	}
 
+	configLogger.Infof("Config file loaded: [%s]", file)
+	return viperByFile, true
+}
+// Initializes viper by external file, the error is viable if the file is not existing or cannot be parsed.
+func readInByFileE(file string) (*viper.Viper, error) {
+	if _, err := os.Stat(file); err != nil {
+		return nil, err
+	}
+
 	viperByFile := viper.New()
 	viperByFile.SetConfigFile(file)
-	if err = viperByFile.ReadInConfig(); err != nil {
-		configLogger.Warn(fmt.Errorf("Read file has error: %v", err))
-		return viperByFile, false
-	} else {
-		configLogger.Infof("Config file loaded: [%s]", file)
+	if err := viperByFile.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("Read file[%s] has error: %w", file, err)
 	}
 
-	return viperByFile, true
+	if ext := filepath.Ext(file); ext == ".env" || ext == ".dotenv" {
+		return normalizeDotenvKeys(viperByFile), nil
+	}
+
+	return viperByFile, nil
+}
+func logFileError(file string, err error, warnNotFound bool) {
+	switch {
+	case os.IsNotExist(err) && warnNotFound:
+		configLogger.Warnf("file could not be found: [%s]", file)
+	case os.IsNotExist(err):
+		configLogger.Debugf("file could not be found: [%s]", file)
+	default:
+		configLogger.Warn(err)
+	}
 }
@@ -95,6 +95,21 @@ var contextOfConfigFileNames = func() {
 			Entry(".properties file", "sample-1.properties", "gdb.key", "3IZg3eQZ"),
 			Entry(".json file", "sample-1.json", "gdb.key", "RxTEHH4s"),
 			Entry(".yaml file", "sample-1.yaml", "gdb.key", "G6Coi2S4"),
+			Entry(".toml file", "sample-1.toml", "gdb.key", "Tq8Jm1Lw"),
+			Entry(".hcl file", "sample-1.hcl", "gdb.key", "Hc4Wn7Pe"),
+			Entry(".env file", "sample-1.env", "gdb.key", "Ev2Rk9Ds"),
+			Entry(".ini file", "sample-1.ini", "gdb.key", "In5Ya3Xo"),
 		)
+
+		It("Default names with profiles", func() {
+			testedWorker := newDefaultFilesWorker("guava")
+			testedWorker.targetDir = currentSrcDir
+
+			testedEnv := fg.EnvBuilder.NewByVipers(testedWorker.loadWithProfiles("p1")...)
+
+			Expect(testedEnv.GetProperty("db.sample.host")).To(BeEquivalentTo("10.31.0.2"))
+			Expect(testedEnv.Typed().GetInt("db.sample.port")).To(BeEquivalentTo(5432))
+			Expect(testedEnv.GetProperty("db.sample.user")).To(BeEquivalentTo("guava-user"))
+		})
 	})
 }
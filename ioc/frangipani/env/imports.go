@@ -0,0 +1,114 @@
+package env
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cast"
+	"github.com/spf13/viper"
+)
+
+// The prefix of imported file which may not be existing, e.g., "optional:secrets.yaml"
+const IMPORT_OPTIONAL_PREFIX = "optional:"
+
+// Puts the imported files(declared by "<prefix>.config.import") after their importing files.
+//
+// The priorities of loaded files are:
+//   1. The importing file
+//   2. The files of profiles of imported file(e.g., "base-<profile>.yaml")
+//   3. The imported file(e.g., "base.yaml")
+//   4. The files imported by the imported file(recursively)
+func (self *filesWorker) withImports(loadedVipers vipers, profiles ...string) vipers {
+	if self.importProp == "" {
+		return loadedVipers
+	}
+
+	allVipers := make(vipers, 0, len(loadedVipers))
+	for _, viperObj := range loadedVipers {
+		allVipers = append(allVipers, viperObj)
+
+		importingFile, _ := filepath.Abs(viperObj.ConfigFileUsed())
+		allVipers = append(allVipers, self.loadImports(
+			viperObj, profiles, map[string]bool{ importingFile: true },
+		)...)
+	}
+
+	return allVipers
+}
+// The "visiting" contains the files on the path of importing, which is used to detect cycles.
+func (self *filesWorker) loadImports(importer *viper.Viper, profiles []string, visiting map[string]bool) vipers {
+	importedVipers := make(vipers, 0)
+	importerDir := filepath.Dir(importer.ConfigFileUsed())
+
+	for _, importEntry := range getImportEntries(importer.Get(self.importProp)) {
+		optional := strings.HasPrefix(importEntry, IMPORT_OPTIONAL_PREFIX)
+		importedFile := strings.TrimSpace(strings.TrimPrefix(importEntry, IMPORT_OPTIONAL_PREFIX))
+		if !filepath.IsAbs(importedFile) {
+			importedFile = filepath.Join(importerDir, importedFile)
+		}
+		importedFile, _ = filepath.Abs(importedFile)
+
+		if visiting[importedFile] {
+			err := fmt.Errorf("Circular import of file[%s] by [%s]", importedFile, importer.ConfigFileUsed())
+			configLogger.Warn(err)
+			self.addError(err)
+			continue
+		}
+
+		/**
+		 * Loads the imported file
+		 */
+		importedViper, err := readInByFileE(importedFile)
+		if err != nil {
+			if optional && os.IsNotExist(err) {
+				configLogger.Debugf("Optional imported file could not be found: [%s]", importedFile)
+				continue
+			}
+
+			logFileError(importedFile, err, true)
+			self.addError(fmt.Errorf("Import file by [%s] has error: %w", importer.ConfigFileUsed(), err))
+			continue
+		}
+		configLogger.Infof("Imported config file loaded: [%s]", importedFile)
+		// :~)
+
+		/**
+		 * Loads the files of profiles of imported file
+		 */
+		for _, profiledFile := range profiledFiles(importedFile, profiles...) {
+			profiledFile = filepath.Join(filepath.Dir(importedFile), profiledFile)
+			if profiledViper, ok := readInByFile(profiledFile, false); ok {
+				importedVipers = append(importedVipers, profiledViper)
+			}
+		}
+		// :~)
+
+		visiting[importedFile] = true
+		importedVipers = append(importedVipers, importedViper)
+		importedVipers = append(importedVipers, self.loadImports(importedViper, profiles, visiting)...)
+		delete(visiting, importedFile)
+	}
+
+	return importedVipers
+}
+
+// The entries could be a list or a string separated by comma
+func getImportEntries(value interface{}) []string {
+	var entries []string
+	if stringValue, ok := value.(string); ok {
+		entries = strings.Split(stringValue, ",")
+	} else {
+		entries = cast.ToStringSlice(value)
+	}
+
+	nonEmptyEntries := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			nonEmptyEntries = append(nonEmptyEntries, entry)
+		}
+	}
+
+	return nonEmptyEntries
+}
@@ -0,0 +1,118 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/mikelue/go-misc/utils"
+	"github.com/spf13/pflag"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Imports of files", func() {
+	tmpWorkingDir := utils.RollbackContainerBuilder.
+		NewTmpDir("fake-wd-import-*")
+	var wdParams utils.Params
+	var chdir utils.RollbackContainer
+	var oldOsArgs []string
+
+	BeforeEach(func() {
+		wdParams, _ = tmpWorkingDir.Setup()
+		newWd := wdParams[utils.PKEY_TEMP_DIR].(string)
+
+		/**
+		 * 1. The default file imports "team/base.yaml" and an optional file(not existing)
+		 * 2. The "team/base.yaml" imports "cycle.yaml", which imports the default file again
+		 * 3. The file of profile for "team/base.yaml"
+		 * 4. The explicit file of configuration imports "team/base.yaml"
+		 */
+		os.Mkdir(fmt.Sprintf("%s/team", newWd), 0755)
+		os.Mkdir(fmt.Sprintf("%s/explicit", newWd), 0755)
+		sampleFiles := map[string]string {
+			"mulberry-config.yaml": "mulberry.config.import: [ team/base.yaml, 'optional:secrets.yaml' ]\ndb.host: main-host\n",
+			"team/base.yaml": "mulberry.config.import: ../cycle.yaml\ndb.host: base-host\ndb.port: 5432\ndb.user: base-user\n",
+			"team/base-p1.yaml": "db.user: p1-user\n",
+			"cycle.yaml": "mulberry.config.import: mulberry-config.yaml\ncache.size: 10\n",
+			"explicit/app.yaml": "mulberry.config.import: ../team/base.yaml\ndb.host: explicit-host\n",
+		}
+		for name, content := range sampleFiles {
+			filename := fmt.Sprintf("%s/%s", newWd, name)
+			if err := ioutil.WriteFile(filename, []byte(content), 0644); err != nil {
+				GinkgoT().Errorf("Unable to write file[%s]: %v", filename, err)
+			}
+		}
+		// :~)
+
+		chdir = utils.RollbackContainerBuilder.NewChdir(newWd)
+		chdir.Setup()
+
+		oldOsArgs = os.Args
+		os.Args = []string {
+			`--mulberry.profiles.active=p1`,
+		}
+	})
+	AfterEach(func() {
+		os.Args = oldOsArgs
+		chdir.TearDown()
+		tmpWorkingDir.TearDown(wdParams)
+	})
+
+	newBuilder := func() *ConfigBuilder {
+		return NewConfigBuilder().
+			Prefix("mulberry").
+			Priority(CL_ARGS, CL_PWD).
+			Pflags(pflag.NewFlagSet("test-imports", pflag.ExitOnError))
+	}
+
+	It("Loads imported files by priority", func() {
+		testedEnv := newBuilder().
+			Build().
+			ParseFlags().
+			Load()
+
+		Expect(testedEnv.GetProperty("db.host")).To(Equal("main-host"))
+		Expect(testedEnv.Typed().GetInt("db.port")).To(Equal(5432))
+		Expect(testedEnv.GetProperty("db.user")).To(Equal("p1-user"))
+		Expect(testedEnv.Typed().GetInt("cache.size")).To(Equal(10))
+	})
+
+	It("Loads imported files of explicit file(with profiles)", func() {
+		os.Args = append(os.Args, `--mulberry.config.files=explicit/app.yaml`)
+
+		testedEnv := newBuilder().
+			Priority(CL_ARGS, CL_CONFIG_FILE).
+			Build().
+			ParseFlags().
+			Load()
+
+		Expect(testedEnv.GetProperty("db.host")).To(Equal("explicit-host"))
+		Expect(testedEnv.Typed().GetInt("db.port")).To(Equal(5432))
+		Expect(testedEnv.GetProperty("db.user")).To(Equal("p1-user"))
+	})
+
+	It("Circular import(strict mode)", func() {
+		_, err := newBuilder().
+			Strict().
+			Build().
+			ParseFlags().
+			TryLoad()
+
+		var loadingErr *LoadingError
+		Expect(errors.As(err, &loadingErr)).To(BeTrue())
+		Expect(loadingErr.Causes).To(HaveLen(1))
+		Expect(err).To(MatchError(MatchRegexp(`Circular import of file\[.+mulberry-config.yaml\]`)))
+	})
+
+	DescribeTable("getImportEntries",
+		func(value interface{}, expected []string) {
+			Expect(getImportEntries(value)).To(Equal(expected))
+		},
+		Entry("List", []interface{}{ "a.yaml", "optional:b.yaml" }, []string{ "a.yaml", "optional:b.yaml" }),
+		Entry("String separated by comma", "a.yaml, b.yaml,", []string{ "a.yaml", "b.yaml" }),
+		Entry("Nil", nil, []string{}),
+	)
+})
@@ -48,8 +48,15 @@ const (
 
 	// The configuration file
 	PROP_CONFIG_FILES = ".config.files"
+	// The files imported by a configuration file(relative to the importing file),
+	// "optional:" prefix could be used for a file which may not be existing.
+	PROP_CONFIG_IMPORT = ".config.import"
 	// The profiles to be activated
 	PROP_PROFILES_ACTIVE = ".profiles.active"
+	// The profiles to be included(could be declared in files)
+	PROP_PROFILES_INCLUDE = ".profiles.include"
+	// The groups of profiles, e.g., "fgapp.profiles.group.prod=db-pg,metrics"
+	PROP_PROFILES_GROUP = ".profiles.group"
 )
 
 // Method space used to construct new instance of "ConfigLoader"
@@ -88,7 +95,17 @@ func NewConfigBuilder() *ConfigBuilder {
 // To load a bunch of sources as "Environment".
 type ConfigLoader interface {
 	// Loads the environment object
+	//
+	// The loaded environment is a "TrackedEnvironment", which keeps origins of properties.
+	//
+	// If the watching is enabled("ConfigBuilder.Watch()"), the loaded environment is a "WatchedEnvironment".
 	Load() fg.Environment
+	// Loads the environment object, the error is viable if the loaded environment is invalid.
+	//
+	// The error would be:
+	//   "*LoadingError" - Any of requested sources cannot be loaded(only for "ConfigBuilder.Strict()")
+	//   "*SchemaError" - The environment violates schemas set by "ConfigBuilder.Schema()"
+	TryLoad() (fg.Environment, error)
 	// Parse the flags
 	ParseFlags() ConfigLoader
 }
@@ -101,13 +118,16 @@ const (
 	// The source comes from arguments:
 	//   --fgapp.config.yaml
 	//   --fgapp.config.json
+	//   --fgapp.config.toml(.hcl, .properties, .dotenv, .ini)
 	//   --fgapp.config.config.files
 	//   --fgapp.config.files
 	//   --fgapp.profiles.active
+	//   --<name of property>(see "ConfigBuilder.PropertyFlags()")
 	CL_ARGS ConfigSource = 2
 	// The source comes from environment variables:
 	//   $FGAPP_CONFIG_YAML
 	//   $FGAPP_CONFIG_JSON
+	//   $FGAPP_CONFIG_TOML(_HCL, _PROPERTIES, _DOTENV, _INI)
 	//   $FGAPP_CONFIG_FILES
 	//   $FGAPP_PROFILES_ACTIVE
 	CL_ENVVAR ConfigSource = 3
@@ -131,6 +151,18 @@ type ConfigBuilder struct {
 	defaultValues *viper.Viper
 	sources []ConfigSource
 	workers map[ConfigSource]loadingWorker
+	decryptor Decryptor
+	schemas []ConfigSchema
+	strict bool
+	// The names of declared properties registered as flags, empty for every registered metadata
+	propertyFlags []string
+	hasPropertyFlags bool
+	// The prefix of environment variables overriding properties by relaxed names
+	envVarOverridePrefix string
+	hasEnvVarOverridePrefix bool
+
+	watching bool
+	listeners []ChangeListener
 }
 // Sets the priority of supported sources.
 //
@@ -174,6 +206,34 @@ func (self *ConfigBuilder) Pflags(newFlags *pflag.FlagSet) *ConfigBuilder {
 	self.flags = newFlags
 	return self
 }
+// Registers typed flags(with usage and default value) of declared properties on the flags(see "Pflags()").
+//
+// The properties are declared by "frangipani.RegisterMetadata()", every registered property is used if no name is given.
+//
+//   NewConfigBuilder().
+//     PropertyFlags("server.port", "server.timeout").
+//     Build().
+//     ParseFlags().Load()
+//
+// The values given by flags(e.g., "--server.port=8080") have priority of "CL_ARGS",
+// the flags are registered only if "CL_ARGS" is one of sources.
+func (self *ConfigBuilder) PropertyFlags(names ...string) *ConfigBuilder {
+	self.propertyFlags = names
+	self.hasPropertyFlags = true
+	return self
+}
+// Sets the prefix of environment variables which override existing properties by relaxed names(of "CL_ENVVAR"),
+// e.g., "APP" for "APP_SERVER_HTTP_PORT" overriding "server.http-port".
+//
+// The empty prefix makes every environment variable(e.g., "SERVER_HTTP_PORT") a candidate.
+//
+// If this method is not called, the environment variables(without prefix) only override the properties
+// declared by "frangipani.RegisterMetadata()", so "JAVA_HOME" would not override an undeclared "java.home".
+func (self *ConfigBuilder) EnvVarOverrides(prefix string) *ConfigBuilder {
+	self.envVarOverridePrefix = prefix
+	self.hasEnvVarOverridePrefix = true
+	return self
+}
 // Sets up the default properties, this has the lowest priority set by "Priority".
 func (self *ConfigBuilder) DefaultWithMap(properties map[string]interface{}) *ConfigBuilder {
 	if properties == nil {
@@ -215,9 +275,48 @@ type configLoaderImpl struct {
 
 	hasConfigFile bool
 }
+// The errors are logged(the loaded environment is still returned)
 func (self *configLoaderImpl) Load() fg.Environment {
-	pass1Env := self.pass1Load()
-	return self.pass2Load(pass1Env)
+	trackedEnv, err := self.loadAndValidate()
+	if err != nil {
+		configLogger.Errorf("Loaded configuration is invalid: %v", err)
+	}
+
+	return self.watchIfEnabled(trackedEnv)
+}
+func (self *configLoaderImpl) TryLoad() (fg.Environment, error) {
+	trackedEnv, err := self.loadAndValidate()
+	if err != nil {
+		return nil, err
+	}
+
+	return self.watchIfEnabled(trackedEnv), nil
+}
+func (self *configLoaderImpl) watchIfEnabled(trackedEnv *trackedEnvImpl) fg.Environment {
+	if self.watching {
+		return newWatchedEnv(self, trackedEnv)
+	}
+
+	return trackedEnv
+}
+func (self *configLoaderImpl) loadAndValidate() (*trackedEnvImpl, error) {
+	trackedEnv := self.load()
+
+	/**
+	 * The errors of decryption are always reported, the ones of sources are only reported in strict mode
+	 */
+	causes := make([]error, 0)
+	if self.strict {
+		causes = append(causes, self.collectLoadingErrors()...)
+	}
+	causes = append(causes, trackedEnv.decryptErrors...)
+
+	if len(causes) > 0 {
+		return trackedEnv, &LoadingError{ causes }
+	}
+	// :~)
+
+	return trackedEnv, validateSchemas(trackedEnv, self.schemas)
 }
 func (self *configLoaderImpl) ParseFlags() ConfigLoader {
 	envArgs := make([]string, 0, 0)
@@ -233,21 +332,53 @@ func (self *configLoaderImpl) ParseFlags() ConfigLoader {
 		}
 	}
 
+	if self.argsConfig != nil {
+		envArgs = append(envArgs, propertyFlagArgs(os.Args, self.argsConfig.propertyFlags)...)
+		self.argsConfig.overrides = argOverrides(os.Args, envPrefix, self.argsConfig.propertyFlags)
+	}
+
 	self.flags.Parse(envArgs)
 	return self
 }
+func (self *configLoaderImpl) load() *trackedEnvImpl {
+	pass1Env := self.pass1Load()
+	return self.pass2Load(pass1Env)
+}
 func (self *configLoaderImpl) init() *configLoaderImpl {
 	for _, source := range self.sources {
 		if source == CL_ARGS {
 			self.argsConfig = new(argsConfig).
 				setPrefix(string(self.prefix)).
 				bindByPflag(self.flags)
+
+			if self.hasPropertyFlags {
+				self.argsConfig.propertyFlags = bindPropertyFlags(self.flags, self.declaredProperties())
+			}
 			break
 		}
 	}
 
 	return self
 }
+// Gets metadata of properties for "ConfigBuilder.PropertyFlags()"
+func (self *configLoaderImpl) declaredProperties() []fg.PropertyMetadata {
+	if len(self.propertyFlags) == 0 {
+		return fg.AllMetadata()
+	}
+
+	declaredMetadata := make([]fg.PropertyMetadata, 0, len(self.propertyFlags))
+	for _, name := range self.propertyFlags {
+		metadata, ok := fg.GetMetadata(name)
+		if !ok {
+			configLogger.Warnf("Property is not declared(by frangipani.RegisterMetadata()): [%s]", name)
+			metadata = fg.PropertyMetadata{ Name: name }
+		}
+
+		declaredMetadata = append(declaredMetadata, metadata)
+	}
+
+	return declaredMetadata
+}
 func (self *configLoaderImpl) pass1Load() fg.Environment {
 	workersMap := make(map[ConfigSource]loadingWorker, len(self.sources))
 	allVipers := make(vipers, 0, len(self.sources))
@@ -268,8 +399,12 @@ func (self *configLoaderImpl) pass1Load() fg.Environment {
 			worker = &emptyLoader
 			self.hasConfigFile = true
 		default:
-			configLogger.Warnf("Unsupported type of source: %v", source)
-			continue
+			registered, ok := getCustomSource(source)
+			if !ok {
+				configLogger.Warnf("Unsupported type of source: %v", source)
+				continue
+			}
+			worker = newCustomWorker(registered)
 		}
 
 		allVipers = append(allVipers, worker.load()...)
@@ -285,20 +420,10 @@ func (self *configLoaderImpl) pass1Load() fg.Environment {
 	}
 	// :~)
 
-	/**
-	 * Sets the property for active profiles
-	 */
-	profiles := self.getProfiles(allVipers)
-	if profiles != "" {
-		allVipers[0].Set(fg.PROP_ACITVE_PROFILES, profiles)
-	}
-	// :~)
-
-
 	self.workers = workersMap
-	return fg.EnvBuilder.NewByVipers(allVipers...)
+	return self.newEnv(allVipers)
 }
-func (self *configLoaderImpl) pass2Load(env fg.Environment) fg.Environment {
+func (self *configLoaderImpl) pass2Load(env fg.Environment) *trackedEnvImpl {
 	/**
 	 * Loads additional files of configuration
 	 */
@@ -307,37 +432,175 @@ func (self *configLoaderImpl) pass2Load(env fg.Environment) fg.Environment {
 			string(self.prefix.withSuffix(PROP_CONFIG_FILES)),
 		)
 		if len(configFiles) > 0 {
-			self.workers[CL_CONFIG_FILE] = workerBuilder.newFiles(configFiles...)
+			self.workers[CL_CONFIG_FILE] = workerBuilder.newFiles(string(self.prefix), configFiles...)
 		}
 	}
 	// :~)
 
+	/**
+	 * The profiles(and groups) may be included by additional files of configuration
+	 */
 	profiles := env.GetActiveProfiles()
+	if self.hasConfigFile {
+		profiles = self.loadWithoutProfiles().GetActiveProfiles()
+	}
+	// :~)
 
 	/**
 	 * Loads vipers with profile
 	 */
 	allVipers := make(vipers, 0, len(self.sources))
+	allSources := make([]*propertySource, 0, len(self.sources))
+	overridePoints := make([]*overridePoint, 0)
 	for _, source := range self.sources {
 		var loadedVipers vipers
+		worker, ok := self.workers[source]
+		if !ok {
+			continue
+		}
 
 		switch source {
 		// Only these sources support profiles
-		case CL_XDG, CL_PWD, CL_CMDDIR:
-			loadedVipers = self.workers[source].loadWithProfiles(profiles...)
+		// (for CL_CONFIG_FILE, the profiles are applied to imported files only)
+		case CL_XDG, CL_PWD, CL_CMDDIR, CL_CONFIG_FILE:
+			loadedVipers = worker.loadWithProfiles(profiles...)
 		default:
-			loadedVipers = self.workers[source].load()
+			if _, ok := worker.(*customWorker); ok {
+				loadedVipers = worker.loadWithProfiles(profiles...)
+				break
+			}
+			loadedVipers = worker.load()
+		}
+
+		if overrider, ok := worker.(relaxedOverrider); ok && len(overrider.relaxedOverrides()) > 0 {
+			overrides := overrider.relaxedOverrides()
+			if source == CL_ENVVAR {
+				overrides = selectEnvVarOverrides(overrides, self.envVarOverridePrefix, self.hasEnvVarOverridePrefix)
+			}
+
+			overridePoints = append(overridePoints, &overridePoint {
+				source, len(allSources), overrides,
+			})
 		}
 
+		for _, loadedViper := range loadedVipers {
+			allSources = append(allSources, &propertySource {
+				source, viperLocation(worker, loadedViper), loadedViper,
+			})
+		}
 		allVipers = append(allVipers, loadedVipers...)
 	}
 	// :~)
 
 	if self.defaultValues != nil {
 		allVipers = append(allVipers, self.defaultValues)
+		allSources = append(allSources, &propertySource {
+			0, LOCATION_DEFAULT_VALUES, self.defaultValues,
+		})
+	}
+
+	/**
+	 * Existing properties could be overridden by environment variables or arguments with relaxed names
+	 */
+	allSources = applyRelaxedOverrides(allSources, overridePoints)
+	// :~)
+
+	/**
+	 * The properties of profiles have the highest priority
+	 */
+	allSources = append(
+		[]*propertySource{
+			{ 0, LOCATION_PROFILES, self.newProfilesViper(allVipers, profiles...) },
+		},
+		allSources...,
+	)
+	// :~)
+
+	trackedEnv := newTrackedEnv(allSources, self.decryptor)
+	trackedEnv.secretKeys = getStringList(
+		trackedEnv, string(self.prefix.withSuffix(PROP_SECRET_KEYS)),
+	)
+
+	/**
+	 * Dumps the loaded properties(values of secret keys are masked)
+	 */
+	if configLogger.IsDebugEnabled() {
+		var dumpedProps strings.Builder
+		trackedEnv.Dump(&dumpedProps)
+		configLogger.Debugf("Loaded properties:\n%s", dumpedProps.String())
+	}
+	// :~)
+
+	for _, warning := range metadataWarnings(trackedEnv) {
+		configLogger.Warn(warning)
+	}
+
+	return trackedEnv
+}
+// Loads environment by every source without profiles(the loaded vipers are cached by workers)
+func (self *configLoaderImpl) loadWithoutProfiles() fg.Environment {
+	allVipers := make(vipers, 0, len(self.sources))
+	for _, source := range self.sources {
+		if worker, ok := self.workers[source]; ok {
+			allVipers = append(allVipers, worker.load()...)
+		}
+	}
+
+	if self.defaultValues != nil {
+		allVipers = append(allVipers, self.defaultValues)
+	}
+
+	return self.newEnv(allVipers)
+}
+// Builds environment with the properties of profiles of frangipani.
+//
+// See "newProfilesViper"
+func (self *configLoaderImpl) newEnv(allVipers vipers) fg.Environment {
+	return fg.EnvBuilder.NewByVipers(
+		append(vipers{ self.newProfilesViper(allVipers) }, allVipers...)...,
+	)
+}
+// Builds the properties of profiles of frangipani,
+// which are set by properties with prefix(e.g., "<prefix>.profiles.active").
+//
+// If "activeProfiles" is viable, the value of "fgapp.profiles.active" would be them.
+func (self *configLoaderImpl) newProfilesViper(allVipers vipers, activeProfiles ...string) *viper.Viper {
+	profilesViper := viper.New()
+
+	/**
+	 * Sets the property for active profiles
+	 */
+	profiles := strings.Join(activeProfiles, ",")
+	if profiles == "" {
+		profiles = self.getProfiles(allVipers)
+	}
+	if profiles != "" {
+		profilesViper.Set(fg.PROP_ACITVE_PROFILES, profiles)
 	}
+	// :~)
+
+	/**
+	 * Sets the properties for included profiles and groups of profiles
+	 */
+	includeProp := string(self.prefix.withSuffix(PROP_PROFILES_INCLUDE))
+	groupProp := string(self.prefix.withSuffix(PROP_PROFILES_GROUP))
+	for i := len(allVipers) - 1; i >= 0; i-- {
+		if allVipers[i].IsSet(includeProp) {
+			profilesViper.Set(fg.PROP_PROFILES_INCLUDE, allVipers[i].Get(includeProp))
+		}
+
+		for _, key := range allVipers[i].AllKeys() {
+			if strings.HasPrefix(key, groupProp + ".") {
+				profilesViper.Set(
+					fg.PROP_PROFILES_GROUP + key[len(groupProp):],
+					allVipers[i].Get(key),
+				)
+			}
+		}
+	}
+	// :~)
 
-	return fg.EnvBuilder.NewByVipers(allVipers...)
+	return profilesViper
 }
 func (self *configLoaderImpl) getProfiles(sources []*viper.Viper) string {
 	profilesProp := self.prefix.withSuffix(PROP_PROFILES_ACTIVE)
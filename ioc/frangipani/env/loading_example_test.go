@@ -3,6 +3,8 @@ package env
 import (
 	"os"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 
 	"github.com/spf13/viper"
 	"github.com/spf13/pflag"
@@ -112,3 +114,73 @@ func ExampleConfigBuilder() {
 	// Output:
 	// guava-linux:8871
 }
+
+func ExampleRegisterSource() {
+	// This is just to make testing re-runnable
+	pflag.CommandLine = pflag.NewFlagSet("ExampleRegisterSource", pflag.ExitOnError)
+
+	// The stub of config server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		profile := r.URL.Query().Get("profile")
+		if profile == "prod" {
+			fmt.Fprint(w, `{ "db.host": "prod-linux" }`)
+			return
+		}
+
+		fmt.Fprint(w, `{ "db.host": "dev-linux", "db.port": 7761 }`)
+	}))
+	defer server.Close()
+
+	CL_CONFIG_SERVER := RegisterSource("config-server", &httpSource{ server.URL })
+
+	typedProperties := NewConfigBuilder().
+		Prefix("quince").
+		Priority(CL_ARGS, CL_CONFIG_SERVER).
+		DefaultWithMap(map[string]interface{} {
+			"quince.profiles.active": "prod",
+		}).
+		Build().
+		ParseFlags().Load().
+		Typed()
+
+	fmt.Printf("%s:%d", typedProperties.GetString("db.host"), typedProperties.GetInt("db.port"))
+
+	// Output:
+	// prod-linux:7761
+}
+
+type httpSource struct {
+	url string
+}
+func (self *httpSource) Load() ([]*viper.Viper, error) {
+	viperObj, err := self.get("")
+	if err != nil {
+		return nil, err
+	}
+
+	return []*viper.Viper{ viperObj }, nil
+}
+func (self *httpSource) LoadProfiles(profiles ...string) ([]*viper.Viper, error) {
+	loadedVipers := make([]*viper.Viper, 0, len(profiles))
+	for _, profile := range profiles {
+		viperObj, err := self.get(profile)
+		if err != nil {
+			return nil, err
+		}
+
+		loadedVipers = append(loadedVipers, viperObj)
+	}
+
+	return loadedVipers, nil
+}
+func (self *httpSource) get(profile string) (*viper.Viper, error) {
+	resp, err := http.Get(fmt.Sprintf("%s/?profile=%s", self.url, profile))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	viperObj := viper.New()
+	viperObj.SetConfigType("json")
+	return viperObj, viperObj.ReadConfig(resp.Body)
+}
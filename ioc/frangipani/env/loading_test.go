@@ -3,6 +3,7 @@ package env
 import (
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 
@@ -471,6 +472,66 @@ var contextOfLoad = func() {
 		})
 	})
 
+	Context("Profile groups and included profiles", func() {
+		tmpWorkingDir := utils.RollbackContainerBuilder.
+			NewTmpDir("fake-wd-pg-*")
+		var wdParams utils.Params
+		var chdir utils.RollbackContainer
+
+		BeforeEach(func() {
+			wdParams, _ = tmpWorkingDir.Setup()
+			newWd := wdParams[utils.PKEY_TEMP_DIR].(string)
+
+			/**
+			 * 1. The group is declared in default file
+			 * 2. The included profile is declared in file of "lime.config.files"
+			 * 3. The files of profiles
+			 */
+			sampleFiles := map[string]string {
+				"lime-config.yaml": "lime.profiles.group.prod: db-pg,metrics\n",
+				"extra-config.yaml": "lime.profiles.include: tls\n",
+				"lime-config-db-pg.yaml": "db.sample.driver: pg\n",
+				"lime-config-tls.yaml": "server.tls: true\n",
+			}
+			for name, content := range sampleFiles {
+				filename := fmt.Sprintf("%s/%s", newWd, name)
+				if err := ioutil.WriteFile(filename, []byte(content), 0644); err != nil {
+					GinkgoT().Errorf("Unable to write file[%s]: %v", filename, err)
+				}
+			}
+			// :~)
+
+			chdir = utils.RollbackContainerBuilder.NewChdir(newWd)
+			chdir.Setup()
+
+			os.Args = []string {
+				`--lime.profiles.active=prod`,
+				`--lime.config.files=extra-config.yaml`,
+			}
+			pflag.CommandLine = pflag.NewFlagSet("test-profile-groups", pflag.ExitOnError)
+		})
+		AfterEach(func() {
+			chdir.TearDown()
+			tmpWorkingDir.TearDown(wdParams)
+		})
+
+		It("Expanded profiles and loaded files of profiles", func() {
+			testedEnv := NewConfigBuilder().
+				Prefix("lime").
+				Priority(CL_ARGS, CL_CONFIG_FILE, CL_PWD).
+				Build().
+				ParseFlags().
+				Load()
+
+			Expect(testedEnv.GetActiveProfiles()).
+				To(Equal([]string{ "prod", "db-pg", "metrics", "tls", fg.DEFAULT_PROFILE }))
+			Expect(testedEnv.GetProperty("db.sample.driver")).
+				To(BeEquivalentTo("pg"))
+			Expect(testedEnv.Typed().GetBool("server.tls")).
+				To(BeTrue())
+		})
+	})
+
 	Context("Customized priority", func() {
 		BeforeEach(func() {
 			os.Args = []string {
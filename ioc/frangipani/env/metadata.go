@@ -0,0 +1,157 @@
+package env
+
+import (
+	"fmt"
+	"strings"
+
+	fg "github.com/mikelue/go-misc/ioc/frangipani"
+)
+
+// The maximum distance(of last segments) between an unknown property and the suggested one
+const maxSuggestionDistance = 2
+// The length of last segment per allowed distance, e.g., 1 for "port", 0 for "url"(no suggestion)
+const suggestionLengthPerDistance = 4
+
+// Checks the loaded properties by registered metadata(see "frangipani.RegisterMetadata()"):
+//
+//   1. The deprecated properties
+//   2. The unknown properties which are similar to registered ones(typos)
+func metadataWarnings(trackedEnv TrackedEnvironment) []string {
+	allMetadata := fg.AllMetadata()
+	if len(allMetadata) == 0 {
+		return nil
+	}
+
+	warnings := make([]string, 0)
+	for _, name := range trackedEnv.GetPropertyNames() {
+		location := ""
+		if origins := trackedEnv.GetOrigins(name); len(origins) > 0 {
+			location = origins[0].String()
+		}
+
+		/**
+		 * Deprecated properties
+		 */
+		if metadata, ok := fg.GetMetadata(name); ok {
+			if !metadata.Deprecated {
+				continue
+			}
+
+			message := fmt.Sprintf("Property[%s](%s) is deprecated", name, location)
+			if metadata.Replacement != "" {
+				message += fmt.Sprintf(", use [%s] instead", metadata.Replacement)
+			}
+			warnings = append(warnings, message)
+			continue
+		}
+		// :~)
+
+		if isUnderRegisteredMap(name, allMetadata) {
+			continue
+		}
+
+		/**
+		 * Typos of registered properties
+		 */
+		if suggestion, ok := suggestProperty(name, allMetadata); ok {
+			warnings = append(warnings, fmt.Sprintf(
+				"Property[%s](%s) is unknown, did you mean [%s]?", name, location, suggestion,
+			))
+		}
+		// :~)
+	}
+
+	return warnings
+}
+
+// The properties of registered map(e.g., "db.options.ssl" of "db.options") are known ones
+func isUnderRegisteredMap(name string, allMetadata []fg.PropertyMetadata) bool {
+	canonicalName := fg.CanonicalName(name)
+
+	for _, metadata := range allMetadata {
+		if strings.HasPrefix(canonicalName, fg.CanonicalName(metadata.Name) + ".") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Finds the nearest(non-deprecated) property by edit distance of last segments(in canonical names),
+// the other segments must be the same.
+//
+// The allowed distance is scaled by the length of last segment(no suggestion for the short ones).
+func suggestProperty(name string, allMetadata []fg.PropertyMetadata) (string, bool) {
+	parent, lastSegment := splitLastSegment(fg.CanonicalName(name))
+
+	maxDistance := minInt(maxSuggestionDistance, len(lastSegment) / suggestionLengthPerDistance)
+	suggestion, minDistance := "", maxDistance + 1
+
+	for _, metadata := range allMetadata {
+		if metadata.Deprecated {
+			continue
+		}
+
+		metadataParent, metadataLastSegment := splitLastSegment(fg.CanonicalName(metadata.Name))
+		if metadataParent != parent {
+			continue
+		}
+
+		distance := editDistance(lastSegment, metadataLastSegment)
+		if distance < minDistance {
+			suggestion, minDistance = metadata.Name, distance
+		}
+	}
+
+	return suggestion, suggestion != ""
+}
+
+func splitLastSegment(name string) (parent string, lastSegment string) {
+	index := strings.LastIndex(name, ".")
+	if index < 0 {
+		return "", name
+	}
+
+	return name[:index], name[index + 1:]
+}
+
+// The Levenshtein distance
+func editDistance(source string, target string) int {
+	previousRow := make([]int, len(target) + 1)
+	for j := range previousRow {
+		previousRow[j] = j
+	}
+
+	for i := 1; i <= len(source); i++ {
+		currentRow := make([]int, len(target) + 1)
+		currentRow[0] = i
+
+		for j := 1; j <= len(target); j++ {
+			cost := 1
+			if source[i - 1] == target[j - 1] {
+				cost = 0
+			}
+
+			currentRow[j] = minInt(
+				previousRow[j] + 1,
+				currentRow[j - 1] + 1,
+				previousRow[j - 1] + cost,
+			)
+		}
+
+		previousRow = currentRow
+	}
+
+	return previousRow[len(target)]
+}
+
+func minInt(first int, others ...int) int {
+	result := first
+	for _, v := range others {
+		if v < result {
+			result = v
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,68 @@
+package env
+
+import (
+	fg "github.com/mikelue/go-misc/ioc/frangipani"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Warnings by metadata", func() {
+	fg.RegisterMetadata(
+		fg.PropertyMetadata{ Name: "lemon.pool.max-size", Type: "int" },
+		fg.PropertyMetadata{ Name: "lemon.host", Type: "string" },
+		fg.PropertyMetadata{ Name: "lemon.port", Type: "int" },
+		fg.PropertyMetadata{ Name: "lemon.db.url", Type: "string" },
+		fg.PropertyMetadata{ Name: "lemon.options", Type: "map[string]string" },
+		fg.PropertyMetadata{ Name: "lemon.hostname", Deprecated: true, Replacement: "lemon.host" },
+		fg.PropertyMetadata{ Name: "lemon.address", Deprecated: true },
+	)
+
+	It("Deprecated and unknown properties", func() {
+		testedEnv := NewConfigBuilder().
+			Prefix("lemon").
+			Priority().
+			DefaultWithMap(map[string]interface{} {
+				"lemon.pool.maxSize": 20,
+				"lemon.hostname": "10.1.1.1",
+				"lemon.address": "10.1.1.2",
+				"lemon.pool.max-sise": 30,
+				"lemon.options.ssl": true,
+				"lemon.completely.different": 1,
+				"lemon.porrt": 8080,
+				"lemon.db.uri": "db://",
+				"lemon.pool.host": "10.1.1.3",
+			}).
+			Build().
+			Load().(TrackedEnvironment)
+
+		Expect(metadataWarnings(testedEnv)).To(ConsistOf(
+			"Property[lemon.address](<default values>) is deprecated",
+			"Property[lemon.hostname](<default values>) is deprecated, use [lemon.host] instead",
+			"Property[lemon.pool.max-sise](<default values>) is unknown, did you mean [lemon.pool.max-size]?",
+			"Property[lemon.porrt](<default values>) is unknown, did you mean [lemon.port]?",
+		))
+	})
+
+	DescribeTable("suggestProperty",
+		func(name string, expected string) {
+			suggestion, _ := suggestProperty(name, fg.AllMetadata())
+			Expect(suggestion).To(Equal(expected))
+		},
+		Entry("Typo of long segment", "lemon.pool.maxSise", "lemon.pool.max-size"),
+		Entry("Typo of short segment", "lemon.porrt", "lemon.port"),
+		Entry("Too short segment", "lemon.db.uri", ""),
+		Entry("Unrelated segments", "lemon.user", ""),
+		Entry("Different parent", "lemon.pool.host", ""),
+	)
+
+	DescribeTable("editDistance",
+		func(source string, target string, expected int) {
+			Expect(editDistance(source, target)).To(Equal(expected))
+		},
+		Entry("Same", "db.host", "db.host", 0),
+		Entry("Substitution", "db.hust", "db.host", 1),
+		Entry("Insertion and deletion", "db.hst", "db.hostt", 2),
+		Entry("Empty", "", "abc", 3),
+	)
+})
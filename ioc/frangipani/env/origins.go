@@ -0,0 +1,359 @@
+package env
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	fg "github.com/mikelue/go-misc/ioc/frangipani"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	// Location of default values(set by "ConfigBuilder.DefaultWithMap" or "ConfigBuilder.DefaultWithViper")
+	LOCATION_DEFAULT_VALUES = "<default values>"
+	// Location of properties of profiles, which are computed by the loader
+	LOCATION_PROFILES = "<profiles>"
+	// Location of generated values of "random.*"(see "frangipani.RANDOM_PREFIX")
+	LOCATION_RANDOM_VALUES = "<random>"
+
+	// The masked text for values of secret keys
+	MASKED_VALUE = "******"
+)
+
+// The keys matching this pattern are treated as secret ones, whose values would be masked while dumping
+var SecretKeyPattern = regexp.MustCompile(`(?i)(password|passwd|secret|token|credential|private[-_.]?key|api[-_.]?key)`)
+
+// Matches the names of placeholders, the second group is "$" if the placeholder is nested(e.g., "${db.${env}.host}")
+var placeholderNamePattern = regexp.MustCompile(`\$\{([^${}:]*)([$}:]?)`)
+
+// The "Environment" loaded by "ConfigLoader.Load()", which keeps origins of properties.
+//
+//   trackedEnv := env.(TrackedEnvironment)
+//   origins := trackedEnv.GetOrigins("db.host")
+type TrackedEnvironment interface {
+	fg.Environment
+
+	// Gets origins of the property(ordered by priority).
+	//
+	// The first one is the winning(effective) origin, others are shadowed by it.
+	//
+	// Returns empty slice if the property is not existing.
+	GetOrigins(name string) []*PropertyOrigin
+	// Gets names of all of the properties(sorted)
+	GetPropertyNames() []string
+	// Dumps the effective properties with their origins(values of secret keys are masked)
+	//
+	// The secret keys are matched by "SecretKeyPattern", "<prefix>.secret.keys", or having encrypted values.
+	Dump(writer io.Writer) error
+}
+
+// Where a value of property comes from
+type PropertyOrigin struct {
+	// Source of property, "0" for default values and properties of profiles.
+	Source ConfigSource
+	// The path of file, the name of flag(e.g., "--fgapp.config.yaml"),
+	// or the name of environment variable(e.g., "$FGAPP_CONFIG_YAML").
+	Location string
+	// The original value(placeholders are not resolved)
+	Value interface{}
+	// The deprecated name(see "frangipani.RegisterAlias()") supplying the value, empty if the property is not supplied by alias.
+	Alias string
+}
+// Formatted as "<source>: <location>", or only the location if it is the same as the name of source(e.g., customized sources).
+func (self *PropertyOrigin) String() string {
+	location := self.Location
+	if sourceName := self.Source.String(); self.Source != 0 && sourceName != self.Location {
+		location = fmt.Sprintf("%s: %s", sourceName, self.Location)
+	}
+
+	if self.Alias != "" {
+		return fmt.Sprintf("%s(alias: %s)", location, self.Alias)
+	}
+
+	return location
+}
+
+func (self ConfigSource) String() string {
+	switch self {
+	case CL_XDG:
+		return "CL_XDG"
+	case CL_ARGS:
+		return "CL_ARGS"
+	case CL_ENVVAR:
+		return "CL_ENVVAR"
+	case CL_CONFIG_FILE:
+		return "CL_CONFIG_FILE"
+	case CL_PWD:
+		return "CL_PWD"
+	case CL_CMDDIR:
+		return "CL_CMDDIR"
+	}
+
+	if registered, ok := getCustomSource(self); ok {
+		return registered.name
+	}
+
+	return fmt.Sprintf("ConfigSource(%d)", int(self))
+}
+
+// Checks whether or not the value of property should be masked
+func isSecretKey(name string) bool {
+	return SecretKeyPattern.MatchString(name)
+}
+
+// A viper with its source and location
+type propertySource struct {
+	source ConfigSource
+	location string
+	viper *viper.Viper
+}
+
+// Implemented by workers which could describe locations of loaded vipers
+type locationDescriber interface {
+	locationOf(*viper.Viper) string
+}
+
+// Gets the location of viper(loaded by the worker)
+func viperLocation(worker loadingWorker, viperObj *viper.Viper) string {
+	if file := viperObj.ConfigFileUsed(); file != "" {
+		return file
+	}
+
+	if describer, ok := worker.(locationDescriber); ok {
+		return describer.locationOf(viperObj)
+	}
+
+	return ""
+}
+
+// The values of "ENC(...)" are decrypted by the decryptor(could be nil), the errors of decryption are kept.
+func newTrackedEnv(sources []*propertySource, decryptor Decryptor) *trackedEnvImpl {
+	keysOfSources := make([]map[string]bool, 0, len(sources))
+	for _, source := range sources {
+		keys := make(map[string]bool)
+		for _, key := range source.viper.AllKeys() {
+			keys[key] = true
+		}
+		keysOfSources = append(keysOfSources, keys)
+	}
+
+	/**
+	 * Use the overriding method to take priority of properties(as "EnvBuilder.NewByVipers()")
+	 */
+	props := make(map[string]interface{})
+	encryptedKeys := make(map[string]bool)
+	decryptErrors := make([]error, 0)
+	for i := len(sources) - 1; i >= 0; i-- {
+		for key := range keysOfSources[i] {
+			value, encrypted, err := decryptValue(decryptor, sources[i].viper.Get(key))
+			if err != nil {
+				err = fmt.Errorf("Unable to decrypt property[%s] of [%s]: %w", key, sources[i].location, err)
+				configLogger.Error(err)
+				decryptErrors = append(decryptErrors, err)
+			}
+			if encrypted {
+				encryptedKeys[key] = true
+			}
+
+			props[key] = value
+		}
+	}
+	// :~)
+
+	return &trackedEnvImpl {
+		Environment: fg.EnvBuilder.NewByMap(props),
+		sources: sources,
+		keysOfSources: keysOfSources,
+		encryptedKeys: encryptedKeys,
+		decryptErrors: decryptErrors,
+	}
+}
+
+type trackedEnvImpl struct {
+	fg.Environment
+
+	sources []*propertySource
+	keysOfSources []map[string]bool
+	// Keys having encrypted values
+	encryptedKeys map[string]bool
+	// The values of "ENC(...)" which cannot be decrypted(reported by "ConfigLoader.TryLoad()")
+	decryptErrors []error
+	// Keys(or prefixes of keys) set by "<prefix>.secret.keys"
+	secretKeys []string
+}
+func (self *trackedEnvImpl) GetOrigins(name string) []*PropertyOrigin {
+	origins := make([]*PropertyOrigin, 0, 1)
+
+	for i, source := range self.sources {
+		if !self.keysOfSources[i][name] {
+			continue
+		}
+
+		origins = append(origins, &PropertyOrigin {
+			Source: source.source,
+			Location: source.location,
+			Value: source.viper.Get(name),
+		})
+	}
+
+	/**
+	 * The value supplied by the deprecated names
+	 */
+	if len(origins) == 0 {
+		origins = self.aliasOrigins(name)
+	}
+	// :~)
+
+	/**
+	 * The generated value of "random.*"
+	 */
+	if len(origins) == 0 && strings.HasPrefix(name, fg.RANDOM_PREFIX) && self.ContainsProperty(name) {
+		origins = append(origins, &PropertyOrigin {
+			Location: LOCATION_RANDOM_VALUES,
+			Value: self.Typed().Get(name),
+		})
+	}
+	// :~)
+
+	return origins
+}
+func (self *trackedEnvImpl) AllProperties() map[string]interface{} {
+	props, _ := fg.AllProperties(self.Environment)
+	return props
+}
+func (self *trackedEnvImpl) GetPropertyNames() []string {
+	uniqueNames := make(map[string]bool)
+	for _, keys := range self.keysOfSources {
+		for key := range keys {
+			uniqueNames[key] = true
+		}
+	}
+
+	names := make([]string, 0, len(uniqueNames))
+	for name := range uniqueNames {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+func (self *trackedEnvImpl) Dump(writer io.Writer) error {
+	for _, name := range self.namesWithAliases() {
+		origins := self.GetOrigins(name)
+
+		if _, err := fmt.Fprintf(writer, "%s = %v [%v]\n",
+			name, self.dumpedValue(name, origins[0].Value, self.Typed().Get(name)), origins[0],
+		); err != nil {
+			return err
+		}
+
+		for _, shadowed := range origins[1:] {
+			if _, err := fmt.Fprintf(writer, "\t(shadowed) %v [%v]\n",
+				self.dumpedValue(name, shadowed.Value, shadowed.Value), shadowed,
+			); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+// Gets origins of the first deprecated name(alias) which is existing
+func (self *trackedEnvImpl) aliasOrigins(name string) []*PropertyOrigin {
+	for _, alias := range fg.GetAliases(name) {
+		for _, existingName := range self.GetPropertyNames() {
+			if squashedName(existingName) != squashedName(alias) {
+				continue
+			}
+
+			origins := self.GetOrigins(existingName)
+			for _, origin := range origins {
+				origin.Alias = existingName
+			}
+
+			return origins
+		}
+	}
+
+	return nil
+}
+// The names of properties with the ones supplied by deprecated names(sorted)
+func (self *trackedEnvImpl) namesWithAliases() []string {
+	names := self.GetPropertyNames()
+
+	allNames := make([]string, 0, len(names))
+	allNames = append(allNames, names...)
+
+	for _, name := range names {
+		metadata, ok := fg.GetMetadata(name)
+		if !ok || !metadata.Deprecated || metadata.Replacement == "" || containsString(allNames, metadata.Replacement) {
+			continue
+		}
+
+		if origins := self.GetOrigins(metadata.Replacement); len(origins) > 0 && origins[0].Alias != "" {
+			allNames = append(allNames, metadata.Replacement)
+		}
+	}
+	sort.Strings(allNames)
+
+	return allNames
+}
+// Checks whether or not the property is secret by:
+//
+//   1. Matching "SecretKeyPattern"
+//   2. Matching "<prefix>.secret.keys"(same key or the parent of key)
+//   3. The value is encrypted
+func (self *trackedEnvImpl) isSecret(name string) bool {
+	if isSecretKey(name) || self.encryptedKeys[name] {
+		return true
+	}
+
+	for _, secretKey := range self.secretKeys {
+		secretKey = strings.ToLower(strings.TrimSpace(secretKey))
+		if name == secretKey || strings.HasPrefix(name, secretKey + ".") {
+			return true
+		}
+	}
+
+	return false
+}
+// Checks whether or not the raw value has placeholders of secret keys(the nested placeholders are treated as secret ones)
+func (self *trackedEnvImpl) referencesSecret(rawValue interface{}) bool {
+	switch typedValue := rawValue.(type) {
+	case string:
+		for _, match := range placeholderNamePattern.FindAllStringSubmatch(typedValue, -1) {
+			if match[2] == "$" || self.isSecret(strings.ToLower(strings.TrimSpace(match[1]))) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, element := range typedValue {
+			if self.referencesSecret(element) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+// Masks the value if the property is secret or its raw value references secret keys
+//
+// The resolved value may contain values of secret keys(e.g., "pg://${db.password}@host").
+func (self *trackedEnvImpl) dumpedValue(name string, rawValue interface{}, value interface{}) interface{} {
+	if self.referencesSecret(rawValue) {
+		return MASKED_VALUE
+	}
+
+	return self.maskedValue(name, value)
+}
+func (self *trackedEnvImpl) maskedValue(name string, value interface{}) interface{} {
+	if self.isSecret(name) {
+		return MASKED_VALUE
+	}
+
+	return value
+}
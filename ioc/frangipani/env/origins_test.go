@@ -0,0 +1,118 @@
+package env
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	fg "github.com/mikelue/go-misc/ioc/frangipani"
+	"github.com/mikelue/go-misc/utils"
+	"github.com/spf13/pflag"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Origins of properties", func() {
+	fg.RegisterAlias("db.sample.address", "db.sample.host")
+
+	var oldOsArgs []string
+	var testedEnv TrackedEnvironment
+	var envContainer utils.RollbackContainer
+
+	BeforeEach(func() {
+		oldOsArgs = os.Args
+		os.Args = []string {
+			`--kiwi.config.yaml={ db.sample.host: 10.20.1.1, db.password: hT7Km }`,
+			fmt.Sprintf(`--kiwi.config.files=%s/split-peas-config.yaml`, currentSrcDir),
+		}
+		pflag.CommandLine = pflag.NewFlagSet("test-origins", pflag.ExitOnError)
+
+		envContainer = utils.RollbackContainerBuilder.NewEnv(map[string]string {
+			"KIWI_CONFIG_JSON": `{ "db.sample.host": "10.20.1.2", "db.port": 5433 }`,
+		})
+		envContainer.Setup()
+
+		testedEnv = NewConfigBuilder().
+			Prefix("kiwi").
+			Priority(CL_ARGS, CL_ENVVAR, CL_CONFIG_FILE).
+			DefaultWithMap(map[string]interface{} {
+				"db.port": 5432,
+				"db.pool.size": 8,
+			}).
+			Build().
+			ParseFlags().
+			Load().(TrackedEnvironment)
+	})
+	AfterEach(func() {
+		os.Args = oldOsArgs
+		envContainer.TearDown()
+	})
+
+	DescribeTable("GetOrigins",
+		func(name string, expected []PropertyOrigin) {
+			testedOrigins := testedEnv.GetOrigins(name)
+
+			Expect(testedOrigins).To(HaveLen(len(expected)))
+			for i, origin := range testedOrigins {
+				Expect(*origin).To(Equal(expected[i]))
+			}
+		},
+		Entry("Shadowed by arguments", "db.sample.host", []PropertyOrigin {
+			{ CL_ARGS, "--kiwi.config.yaml", "10.20.1.1", "" },
+			{ CL_ENVVAR, "$KIWI_CONFIG_JSON", "10.20.1.2", "" },
+			{ CL_CONFIG_FILE, fmt.Sprintf("%s/split-peas-config.yaml", currentSrcDir), "192.186.21.50", "" },
+		}),
+		Entry("Shadowed by environment variable", "db.port", []PropertyOrigin {
+			{ CL_ENVVAR, "$KIWI_CONFIG_JSON", float64(5433), "" },
+			{ 0, LOCATION_DEFAULT_VALUES, 5432, "" },
+		}),
+		Entry("Default value", "db.pool.size", []PropertyOrigin {
+			{ 0, LOCATION_DEFAULT_VALUES, 8, "" },
+		}),
+		Entry("Supplied by alias", "db.sample.address", []PropertyOrigin {
+			{ CL_ARGS, "--kiwi.config.yaml", "10.20.1.1", "db.sample.host" },
+			{ CL_ENVVAR, "$KIWI_CONFIG_JSON", "10.20.1.2", "db.sample.host" },
+			{ CL_CONFIG_FILE, fmt.Sprintf("%s/split-peas-config.yaml", currentSrcDir), "192.186.21.50", "db.sample.host" },
+		}),
+		Entry("Not existing", "db.not-existing", []PropertyOrigin {}),
+	)
+
+	It("Generated value of \"random.*\"", func() {
+		testedOrigins := testedEnv.GetOrigins("random.int(1,10)")
+
+		Expect(testedOrigins).To(HaveLen(1))
+		Expect(testedOrigins[0].Location).To(Equal(LOCATION_RANDOM_VALUES))
+		Expect(testedOrigins[0].Value).To(Equal(testedEnv.Typed().GetInt("random.int(1,10)")))
+	})
+
+	It("GetPropertyNames", func() {
+		Expect(testedEnv.GetPropertyNames()).To(ContainElements(
+			"db.sample.host", "db.password", "db.port", "db.pool.size", "cassandra.port",
+		))
+	})
+
+	It("Dump", func() {
+		var output strings.Builder
+
+		Expect(testedEnv.Dump(&output)).To(Succeed())
+		Expect(output.String()).To(And(
+			ContainSubstring("db.sample.host = 10.20.1.1 [CL_ARGS: --kiwi.config.yaml]\n" +
+				"\t(shadowed) 10.20.1.2 [CL_ENVVAR: $KIWI_CONFIG_JSON]\n"),
+			ContainSubstring("db.password = ****** [CL_ARGS: --kiwi.config.yaml]\n"),
+			ContainSubstring("db.pool.size = 8 [<default values>]\n"),
+			ContainSubstring("db.sample.address = 10.20.1.1 [CL_ARGS: --kiwi.config.yaml(alias: db.sample.host)]\n"),
+			Not(ContainSubstring("hT7Km")),
+		))
+	})
+})
+
+var _ = DescribeTable("isSecretKey",
+	func(name string, expected bool) {
+		Expect(isSecretKey(name)).To(BeEquivalentTo(expected))
+	},
+	Entry("password", "db.password", true),
+	Entry("token(upper case)", "auth.ACCESS_TOKEN", true),
+	Entry("api key", "mail.api-key", true),
+	Entry("not a secret", "db.sample.key", false),
+)
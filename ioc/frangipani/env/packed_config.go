@@ -1,6 +1,7 @@
 package env
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/spf13/viper"
@@ -10,36 +11,66 @@ import (
 //
 // From a content as JSON format
 // From a content as YAML format
+// From a content as TOML, HCL, properties, dotenv, or INI format
 // From a name(path) of file
 // From a content as active profiles
 type packedConfig struct {
 	prefix prefixHolder
 	jsonProps string
 	yamlProps string
+	tomlProps string
+	hclProps string
+	propertiesProps string
+	dotenvProps string
+	iniProps string
 	externalFiles string
 	activeProfiles string
+	names packedNames
 
 	loadedVipers vipers
+	// Locations(names of flags or environment variables) of loaded vipers
+	locations map[*viper.Viper]string
+	// Errors of formatted properties
+	errs []error
+	// Values overriding existing properties by relaxed names(e.g., "SERVER_HTTP_PORT")
+	overrides []*relaxedOverride
+	// Flags of declared properties(see "ConfigBuilder.PropertyFlags()")
+	propertyFlags []*propertyFlag
 }
 func (self *packedConfig) loadFormattedProps() vipers {
 	loadedVipers := make(vipers, 0)
 
-	if self.yamlProps != "" {
-		configLogger.Debugf("Reading properties by JSON")
-		if viperObj, ok := readInByString(
-			FLAG_CONFIG_YAML, "yaml", self.yamlProps,
-		); ok {
-			loadedVipers = append(loadedVipers, viperObj)
-		}
+	/**
+	 * The formats are ordered by priority
+	 */
+	formattedProps := []struct {
+		prop, contentType, content, location string
+	} {
+		{ FLAG_CONFIG_YAML, "yaml", self.yamlProps, self.names.yaml },
+		{ FLAG_CONFIG_JSON, "json", self.jsonProps, self.names.json },
+		{ FLAG_CONFIG_TOML, "toml", self.tomlProps, self.names.toml },
+		{ FLAG_CONFIG_HCL, "hcl", self.hclProps, self.names.hcl },
+		{ FLAG_CONFIG_PROPERTIES, "properties", self.propertiesProps, self.names.properties },
+		{ FLAG_CONFIG_DOTENV, "dotenv", self.dotenvProps, self.names.dotenv },
+		{ FLAG_CONFIG_INI, "ini", self.iniProps, self.names.ini },
 	}
+	// :~)
 
-	if self.jsonProps != "" {
-		configLogger.Debugf("Reading properties by YAML")
-		if viperObj, ok := readInByString(
-			FLAG_CONFIG_JSON, "json", self.jsonProps,
-		); ok {
-			loadedVipers = append(loadedVipers, viperObj)
+	for _, formatted := range formattedProps {
+		if formatted.content == "" {
+			continue
 		}
+
+		configLogger.Debugf("Reading properties by %s", strings.ToUpper(formatted.contentType))
+		viperObj, err := readInByStringE(formatted.contentType, formatted.content)
+		if err != nil {
+			configLogger.Warnf(`Read property "%s" has error(should be %s): %v`,
+				formatted.prop, strings.ToUpper(formatted.contentType), err)
+			self.errs = append(self.errs, fmt.Errorf("Read %s has error: %w", formatted.location, err))
+			continue
+		}
+
+		loadedVipers = append(loadedVipers, self.located(viperObj, formatted.location))
 	}
 
 	return loadedVipers
@@ -63,7 +94,7 @@ func (self *packedConfig) configFileProp() *viper.Viper {
 		self.prefixKey(PROP_CONFIG_FILES),
 		filesAsSlice,
 	)
-	return viperObj
+	return self.located(viperObj, self.names.files)
 }
 func (self *packedConfig) load() vipers {
 	if self.loadedVipers != nil {
@@ -76,6 +107,7 @@ func (self *packedConfig) load() vipers {
 		finalVipers = append(finalVipers, activeProfiles)
 	}
 
+	finalVipers = append(finalVipers, self.loadPropertyFlags()...)
 	finalVipers = append(finalVipers, self.loadFormattedProps()...)
 
 	if externalFilesViper := self.configFileProp(); externalFilesViper != nil {
@@ -105,21 +137,73 @@ func (self *packedConfig) loadActiveProfiles() *viper.Viper {
 	)
 	// :~)
 
-	return activeProfiles
+	return self.located(activeProfiles, self.names.profiles)
+}
+func (self *packedConfig) loadingErrors() []error {
+	return self.errs
 }
 func (self *packedConfig) prefixKey(key string) string {
 	return string(self.prefix.withSuffix(key))
 }
+func (self *packedConfig) locationOf(viperObj *viper.Viper) string {
+	return self.locations[viperObj]
+}
+// Keeps the location of viper
+func (self *packedConfig) located(viperObj *viper.Viper, location string) *viper.Viper {
+	if self.locations == nil {
+		self.locations = make(map[*viper.Viper]string)
+	}
+
+	self.locations[viperObj] = location
+	return viperObj
+}
+
+// Names(of flags or environment variables) of packed sources, which are used as locations of properties
+type packedNames struct {
+	json string
+	yaml string
+	toml string
+	hcl string
+	properties string
+	dotenv string
+	ini string
+	files string
+	profiles string
+}
 
 // Initializes viper by content of string
-func readInByString(prop string, contentType string, content string) (*viper.Viper, bool) {
+func readInByStringE(contentType string, content string) (*viper.Viper, error) {
 	viperObj := viper.New()
 	viperObj.SetConfigType(contentType)
 
 	if err := viperObj.ReadConfig(strings.NewReader(content)); err != nil {
-		configLogger.Warnf(`Read property "%s" has error(should be YAML): %v`, prop, err)
-		return nil, false
+		return nil, err
+	}
+
+	if contentType == "dotenv" {
+		return normalizeDotenvKeys(viperObj), nil
+	}
+
+	return viperObj, nil
+}
+
+// The keys of dotenv are separated by "_"(e.g., "SERVER_PORT" to "server.port"), the dotted keys are kept as they are.
+//
+// The "-" is not allowed by keys of dotenv, so the names like "server.http-port" cannot be set by this format.
+func normalizeDotenvKeys(viperObj *viper.Viper) *viper.Viper {
+	normalizedViper := viper.New()
+	normalizedViper.SetConfigFile(viperObj.ConfigFileUsed())
+
+	for _, key := range viperObj.AllKeys() {
+		name := key
+		if !strings.Contains(key, ".") {
+			name = strings.Join(
+				strings.FieldsFunc(key, func(r rune) bool { return r == '_' }), ".",
+			)
+		}
+
+		normalizedViper.Set(name, viperObj.Get(key))
 	}
 
-	return viperObj, true
+	return normalizedViper
 }
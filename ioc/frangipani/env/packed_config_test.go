@@ -10,19 +10,31 @@ import (
 var _ = Describe("Packed configurations", func() {
 	Context("packedConfig", contextOfPackedConfig)
 
-	Context("readInByString", func() {
+	Context("readInByStringE", func() {
 		It("Successful read in", func() {
-			viper, isSuccess := readInByString("sample.prop.json", "json", `{ "some.key1": 20 }`)
+			viper, err := readInByStringE("json", `{ "some.key1": 20 }`)
 
-			Expect(isSuccess).To(BeTrue())
+			Expect(err).To(Succeed())
 			Expect(viper.GetInt("some.key1")).To(BeEquivalentTo(20))
 		})
 		It("Failed read in", func() {
-			viper, isSuccess := readInByString("sample.prop.json", "json", `{ some.key1: 20 }`)
+			viper, err := readInByStringE("json", `{ some.key1: 20 }`)
 
-			Expect(isSuccess).To(BeFalse())
+			Expect(err).To(HaveOccurred())
 			Expect(viper).To(BeNil())
 		})
+
+		DescribeTable("Keys of dotenv",
+			func(content string, name string, expected string) {
+				viper, err := readInByStringE("dotenv", content)
+
+				Expect(err).To(Succeed())
+				Expect(viper.GetString(name)).To(Equal(expected))
+			},
+			Entry("Separated by \"_\"", "SERVER_PORT=8080", "server.port", "8080"),
+			Entry("Dotted key", "server.max_conn=64", "server.max_conn", "64"),
+			Entry("Redundant \"_\"", "_DB__HOST_=10.1.1.1", "db.host", "10.1.1.1"),
+		)
 	})
 })
 
@@ -0,0 +1,195 @@
+package env
+
+import (
+	"strings"
+	"time"
+
+	fg "github.com/mikelue/go-misc/ioc/frangipani"
+
+	"github.com/spf13/cast"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// A flag of declared property, e.g., "--server.port=8080"
+type propertyFlag struct {
+	name string
+	flag *pflag.Flag
+	// Gets the typed value of flag
+	valueOf func() interface{}
+}
+
+// Registers flags of properties by metadata(see "frangipani.RegisterMetadata()").
+//
+// The type of flag is decided by "PropertyMetadata.Type":
+//
+//   "bool", "int", "int32", "int64", "uint", "uint32", "uint64", "float32", "float64", "duration",
+//   "[]string", "[]int", "[]bool", "[]duration"
+//
+// Other types are registered as string flags(converted while getting properties).
+//
+// The flags of deprecated properties are hidden from usage("pflag.FlagSet.MarkDeprecated()").
+//
+// The existing flag(e.g., defined by application) is reused,
+// whose value is given as text(converted while getting properties).
+func bindPropertyFlags(flagSet *pflag.FlagSet, allMetadata []fg.PropertyMetadata) []*propertyFlag {
+	propertyFlags := make([]*propertyFlag, 0, len(allMetadata))
+
+	for _, metadata := range allMetadata {
+		if existingFlag := flagSet.Lookup(metadata.Name); existingFlag != nil {
+			configLogger.Debugf("Flag of property is existing(reused): [%s]", metadata.Name)
+
+			propertyFlags = append(propertyFlags, &propertyFlag {
+				metadata.Name, existingFlag, textOfFlag(existingFlag),
+			})
+			continue
+		}
+
+		valueOf := definePropertyFlag(flagSet, metadata)
+		if metadata.Deprecated {
+			message := "deprecated"
+			if metadata.Replacement != "" {
+				message = "use --" + metadata.Replacement + " instead"
+			}
+			flagSet.MarkDeprecated(metadata.Name, message)
+		}
+
+		propertyFlags = append(propertyFlags, &propertyFlag {
+			metadata.Name, flagSet.Lookup(metadata.Name), valueOf,
+		})
+	}
+
+	return propertyFlags
+}
+
+func definePropertyFlag(flagSet *pflag.FlagSet, metadata fg.PropertyMetadata) func() interface{} {
+	name, usage, defaultValue := metadata.Name, metadata.Description, metadata.Default
+
+	switch strings.ToLower(strings.ReplaceAll(metadata.Type, " ", "")) {
+	case "bool":
+		v := flagSet.Bool(name, cast.ToBool(defaultValue), usage)
+		return func() interface{} { return *v }
+	case "int":
+		v := flagSet.Int(name, cast.ToInt(defaultValue), usage)
+		return func() interface{} { return *v }
+	case "int32":
+		v := flagSet.Int32(name, cast.ToInt32(defaultValue), usage)
+		return func() interface{} { return *v }
+	case "int64":
+		v := flagSet.Int64(name, cast.ToInt64(defaultValue), usage)
+		return func() interface{} { return *v }
+	case "uint":
+		v := flagSet.Uint(name, cast.ToUint(defaultValue), usage)
+		return func() interface{} { return *v }
+	case "uint32":
+		v := flagSet.Uint32(name, cast.ToUint32(defaultValue), usage)
+		return func() interface{} { return *v }
+	case "uint64":
+		v := flagSet.Uint64(name, cast.ToUint64(defaultValue), usage)
+		return func() interface{} { return *v }
+	case "float32":
+		v := flagSet.Float32(name, cast.ToFloat32(defaultValue), usage)
+		return func() interface{} { return *v }
+	case "float64":
+		v := flagSet.Float64(name, cast.ToFloat64(defaultValue), usage)
+		return func() interface{} { return *v }
+	case "duration", "time.duration":
+		v := flagSet.Duration(name, cast.ToDuration(defaultValue), usage)
+		return func() interface{} { return *v }
+	case "[]string":
+		v := flagSet.StringSlice(name, splitDefaultValue(defaultValue), usage)
+		return func() interface{} { return *v }
+	case "[]int":
+		v := flagSet.IntSlice(name, cast.ToIntSlice(splitDefaultValue(defaultValue)), usage)
+		return func() interface{} { return *v }
+	case "[]bool":
+		v := flagSet.BoolSlice(name, cast.ToBoolSlice(splitDefaultValue(defaultValue)), usage)
+		return func() interface{} { return *v }
+	case "[]duration", "[]time.duration":
+		v := flagSet.DurationSlice(name, toDurationSlice(splitDefaultValue(defaultValue)), usage)
+		return func() interface{} { return *v }
+	}
+
+	v := flagSet.String(name, defaultValue, usage)
+	return func() interface{} { return *v }
+}
+
+// Gets the value of flag as text(or slice of texts)
+func textOfFlag(flag *pflag.Flag) func() interface{} {
+	return func() interface{} {
+		if sliceValue, ok := flag.Value.(pflag.SliceValue); ok {
+			return sliceValue.GetSlice()
+		}
+
+		return flag.Value.String()
+	}
+}
+
+// Builds viper for every flag given by arguments(the flags not given are skipped).
+func (self *packedConfig) loadPropertyFlags() vipers {
+	loadedVipers := make(vipers, 0)
+
+	for _, propertyFlag := range self.propertyFlags {
+		if !propertyFlag.flag.Changed {
+			continue
+		}
+
+		viperObj := viper.New()
+		viperObj.Set(propertyFlag.name, propertyFlag.valueOf())
+		loadedVipers = append(loadedVipers, self.located(viperObj, "--" + propertyFlag.name))
+	}
+
+	return loadedVipers
+}
+
+// Gets the arguments of property flags, e.g., "--server.port=8080", "--server.port 8080", or "--debug"(for bool flags)
+func propertyFlagArgs(args []string, propertyFlags []*propertyFlag) []string {
+	flagArgs := make([]string, 0)
+
+	for i := 0; i < len(args); i++ {
+		for _, propertyFlag := range propertyFlags {
+			flagName := "--" + propertyFlag.name
+
+			if strings.HasPrefix(args[i], flagName + "=") {
+				flagArgs = append(flagArgs, args[i])
+				break
+			}
+
+			if args[i] == flagName {
+				flagArgs = append(flagArgs, args[i])
+
+				/**
+				 * The value is the next argument(except bool flags)
+				 */
+				if propertyFlag.flag.NoOptDefVal == "" && i + 1 < len(args) {
+					i++
+					flagArgs = append(flagArgs, args[i])
+				}
+				// :~)
+				break
+			}
+		}
+	}
+
+	return flagArgs
+}
+
+func splitDefaultValue(defaultValue string) []string {
+	values := make([]string, 0)
+
+	for _, value := range strings.Split(defaultValue, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+func toDurationSlice(values []string) []time.Duration {
+	durations := make([]time.Duration, 0, len(values))
+	for _, value := range values {
+		durations = append(durations, cast.ToDuration(value))
+	}
+
+	return durations
+}
@@ -0,0 +1,135 @@
+package env
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	fg "github.com/mikelue/go-misc/ioc/frangipani"
+	"github.com/spf13/pflag"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Flags of properties", func() {
+	fg.RegisterMetadata(
+		fg.PropertyMetadata{ Name: "melon.server.port", Type: "int", Default: "8080", Description: "Port of server" },
+		fg.PropertyMetadata{ Name: "melon.server.timeout", Type: "duration", Default: "5s" },
+		fg.PropertyMetadata{ Name: "melon.server.hosts", Type: "[]string" },
+		fg.PropertyMetadata{ Name: "melon.server.debug", Type: "bool" },
+		fg.PropertyMetadata{ Name: "melon.server.ports", Type: "[]int" },
+		fg.PropertyMetadata{ Name: "melon.server.address", Deprecated: true, Replacement: "melon.server.hosts" },
+	)
+
+	var oldOsArgs []string
+	var flagSet *pflag.FlagSet
+	var testedEnv TrackedEnvironment
+
+	BeforeEach(func() {
+		oldOsArgs = os.Args
+		os.Args = []string {
+			"melon-app",
+			`--honeydew.config.yaml={ melon.server: { port: 9090, timeout: 10s, name: sample } }`,
+			`--melon.server.port=9091`,
+			`--melon.server.hosts`, `10.1.1.1,10.1.1.2`,
+			`--melon.server.debug`,
+			`--melon.server.name=sample-2`,
+		}
+
+		flagSet = pflag.NewFlagSet("test-property-flags", pflag.ContinueOnError)
+		testedEnv = NewConfigBuilder().
+			Prefix("honeydew").
+			Priority(CL_ARGS).
+			Pflags(flagSet).
+			PropertyFlags(
+				"melon.server.port", "melon.server.timeout", "melon.server.hosts",
+				"melon.server.debug", "melon.server.ports", "melon.server.address",
+			).
+			Build().
+			ParseFlags().
+			Load().(TrackedEnvironment)
+	})
+	AfterEach(func() {
+		os.Args = oldOsArgs
+	})
+
+	It("Typed values given by flags", func() {
+		Expect(testedEnv.Typed().Get("melon.server.port")).To(Equal(9091))
+		Expect(testedEnv.Typed().GetStringSlice("melon.server.hosts")).To(Equal([]string{ "10.1.1.1", "10.1.1.2" }))
+		Expect(testedEnv.Typed().Get("melon.server.debug")).To(Equal(true))
+	})
+
+	It("Flags not given", func() {
+		Expect(testedEnv.Typed().GetDuration("melon.server.timeout")).To(Equal(10 * time.Second))
+		Expect(testedEnv.ContainsProperty("melon.server.ports")).To(BeFalse())
+	})
+
+	It("Existing flags are reused", func() {
+		os.Args = []string {
+			"melon-app",
+			`--melon.server.port=9092`,
+			`--melon.server.hosts`, `10.1.1.3,10.1.1.4`,
+		}
+
+		/**
+		 * The flags defined by application
+		 */
+		existingFlagSet := pflag.NewFlagSet("test-existing-flags", pflag.ContinueOnError)
+		existingFlagSet.Int("melon.server.port", 80, "Port defined by application")
+		existingFlagSet.StringSlice("melon.server.hosts", nil, "Hosts defined by application")
+		// :~)
+
+		reusedEnv := NewConfigBuilder().
+			Prefix("honeydew").
+			Priority(CL_ARGS).
+			Pflags(existingFlagSet).
+			PropertyFlags("melon.server.port", "melon.server.hosts").
+			Build().
+			ParseFlags().
+			Load()
+
+		Expect(reusedEnv.Typed().GetInt("melon.server.port")).To(Equal(9092))
+		Expect(reusedEnv.Typed().GetStringSlice("melon.server.hosts")).To(Equal([]string{ "10.1.1.3", "10.1.1.4" }))
+		Expect(existingFlagSet.Lookup("melon.server.port").Usage).To(Equal("Port defined by application"))
+	})
+
+	It("Not declared property(overriding by relaxed name)", func() {
+		Expect(testedEnv.GetProperty("melon.server.name")).To(Equal("sample-2"))
+	})
+
+	It("Origins", func() {
+		origins := testedEnv.GetOrigins("melon.server.port")
+
+		Expect(origins).To(HaveLen(2))
+		Expect(origins[0].String()).To(Equal("CL_ARGS: --melon.server.port"))
+		Expect(origins[1].String()).To(Equal("CL_ARGS: --honeydew.config.yaml"))
+	})
+
+	It("Usage", func() {
+		usage := flagSet.FlagUsages()
+
+		Expect(usage).To(ContainSubstring("--melon.server.port int"))
+		Expect(usage).To(ContainSubstring("Port of server (default 8080)"))
+		Expect(usage).To(ContainSubstring("--melon.server.timeout duration"))
+		Expect(usage).To(ContainSubstring("--melon.server.hosts strings"))
+		Expect(usage).NotTo(ContainSubstring("melon.server.address"))
+	})
+
+	DescribeTable("propertyFlagArgs",
+		func(args string, expected []string) {
+			Expect(propertyFlagArgs(strings.Fields(args), testedEnvFlags(flagSet))).To(Equal(expected))
+		},
+		Entry("With \"=\"", "--melon.server.port=80 --other=1", []string{ "--melon.server.port=80" }),
+		Entry("Separated value", "--melon.server.port 80 --other 1", []string{ "--melon.server.port", "80" }),
+		Entry("Bool flag", "--melon.server.debug --other", []string{ "--melon.server.debug" }),
+		Entry("Not property flags", "--melon.server --other=1", []string{}),
+	)
+})
+
+func testedEnvFlags(flagSet *pflag.FlagSet) []*propertyFlag {
+	return []*propertyFlag {
+		{ name: "melon.server.port", flag: flagSet.Lookup("melon.server.port") },
+		{ name: "melon.server.debug", flag: flagSet.Lookup("melon.server.debug") },
+	}
+}
@@ -0,0 +1,354 @@
+package env
+
+import (
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cast"
+	"github.com/spf13/viper"
+
+	fg "github.com/mikelue/go-misc/ioc/frangipani"
+)
+
+// An overriding value of existing property by relaxed name,
+// e.g., "SERVER_HTTP_PORT=8080", "--server.http-port=8080", or "SERVERS_0_HOST=10.1.1.1".
+type relaxedOverride struct {
+	// The name of environment variable(e.g., "$SERVER_HTTP_PORT") or flag(e.g., "--server.http-port")
+	location string
+	// The lower-case segments of name, e.g., [ "servers", "0", "host" ]
+	segments []string
+	value string
+	// Only the properties declared by metadata(see "frangipani.RegisterMetadata()") are overridden
+	declaredOnly bool
+}
+
+// Implemented by workers which have values overriding existing properties by relaxed names
+type relaxedOverrider interface {
+	relaxedOverrides() []*relaxedOverride
+}
+
+func (self *packedConfig) relaxedOverrides() []*relaxedOverride {
+	return self.overrides
+}
+
+// The environment variables having multiple segments(e.g., "SERVER_PORT") are candidates,
+// only the ones matching existing properties take effect(see "selectEnvVarOverrides()").
+//
+// The ones having single segment(e.g., "PATH", "HOME", or "USER") are skipped,
+// which are too general to be relaxed names of properties.
+func envVarOverrides() []*relaxedOverride {
+	overrides := make([]*relaxedOverride, 0)
+
+	for _, envVar := range os.Environ() {
+		separatorIndex := strings.Index(envVar, "=")
+		if separatorIndex <= 0 {
+			continue
+		}
+
+		name := envVar[:separatorIndex]
+		segments := nameSegments(name)
+		if len(segments) < 2 {
+			continue
+		}
+
+		overrides = append(overrides, &relaxedOverride {
+			location: "$" + name,
+			segments: segments,
+			value: envVar[separatorIndex + 1:],
+		})
+	}
+
+	return overrides
+}
+
+// Selects the overrides of environment variables by the prefix(see "ConfigBuilder.EnvVarOverrides()"):
+//
+//   No prefix is set - Only the properties declared by metadata are overridden, e.g., "SERVER_PORT" for "server.port"
+//   Empty prefix - Every environment variable is a candidate
+//   Non-empty prefix - The environment variables having the prefix are candidates, e.g., "APP_SERVER_PORT" for "server.port"
+func selectEnvVarOverrides(overrides []*relaxedOverride, prefix string, hasPrefix bool) []*relaxedOverride {
+	prefixSegments := nameSegments(prefix)
+	selected := make([]*relaxedOverride, 0, len(overrides))
+
+	for _, override := range overrides {
+		if !hasPrefix {
+			declaredOverride := *override
+			declaredOverride.declaredOnly = true
+			selected = append(selected, &declaredOverride)
+			continue
+		}
+
+		if len(override.segments) <= len(prefixSegments) ||
+			strings.Join(override.segments[:len(prefixSegments)], "_") != strings.Join(prefixSegments, "_") {
+			continue
+		}
+
+		prefixedOverride := *override
+		prefixedOverride.segments = override.segments[len(prefixSegments):]
+		selected = append(selected, &prefixedOverride)
+	}
+
+	return selected
+}
+
+// The arguments as "--<name>=<value>"(excluding ones having prefix of flags or being flags of properties) are candidates.
+func argOverrides(args []string, excludedPrefix string, propertyFlags []*propertyFlag) []*relaxedOverride {
+	overrides := make([]*relaxedOverride, 0)
+
+	for _, arg := range args {
+		separatorIndex := strings.Index(arg, "=")
+		if !strings.HasPrefix(arg, "--") || strings.HasPrefix(arg, excludedPrefix) || separatorIndex <= 2 {
+			continue
+		}
+
+		name := arg[:separatorIndex]
+		if isPropertyFlag(name[2:], propertyFlags) {
+			continue
+		}
+		overrides = append(overrides, &relaxedOverride {
+			location: name,
+			segments: nameSegments(name[2:]),
+			value: arg[separatorIndex + 1:],
+		})
+	}
+
+	return overrides
+}
+
+func isPropertyFlag(name string, propertyFlags []*propertyFlag) bool {
+	for _, propertyFlag := range propertyFlags {
+		if propertyFlag.name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Splits name by ".", "_", "-", "[", and "]", e.g., "SERVERS_0_HOST" to [ "servers", "0", "host" ]
+func nameSegments(name string) []string {
+	return strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return strings.ContainsRune("._-[]", r)
+	})
+}
+
+// Removes the separators of name, e.g., "server.http-port" to "serverhttpport"
+func squashedName(name string) string {
+	return strings.Join(nameSegments(name), "")
+}
+
+// Inserts the sources of matched overrides(before sources of the workers which have them).
+//
+// The "overridePoints" are the indexes of sources of workers, which are ordered by priority.
+func applyRelaxedOverrides(sources []*propertySource, overridePoints []*overridePoint) []*propertySource {
+	/**
+	 * Builds the existing names of properties by their squashed ones
+	 */
+	namesBySquashed := make(map[string][]string)
+	for _, source := range sources {
+		for _, key := range source.viper.AllKeys() {
+			squashed := squashedName(key)
+			if !containsString(namesBySquashed[squashed], key) {
+				namesBySquashed[squashed] = append(namesBySquashed[squashed], key)
+			}
+		}
+	}
+	for _, names := range namesBySquashed {
+		sort.Strings(names)
+	}
+	// :~)
+
+	/**
+	 * The later points are processed first, so the indexes of former points are not affected
+	 */
+	for i := len(overridePoints) - 1; i >= 0; i-- {
+		point := overridePoints[i]
+		overridingSources := make([]*propertySource, 0)
+
+		for _, override := range point.overrides {
+			name, value, ok := matchOverride(sources, point.index, namesBySquashed, override)
+			if !ok {
+				continue
+			}
+
+			configLogger.Debugf("Property[%s] is overridden by [%s]", name, override.location)
+
+			overridingViper := viper.New()
+			overridingViper.Set(name, value)
+			overridingSources = append(overridingSources, &propertySource {
+				point.source, override.location, overridingViper,
+			})
+		}
+
+		sources = append(
+			sources[:point.index],
+			append(overridingSources, sources[point.index:]...)...,
+		)
+	}
+	// :~)
+
+	return sources
+}
+
+// The position of sources to put overriding values
+type overridePoint struct {
+	source ConfigSource
+	index int
+	overrides []*relaxedOverride
+}
+
+// Finds the existing property matched by the override.
+//
+// The segments are matched by every part(separated by ".") of the name,
+// e.g., "SERVER_HTTP_PORT" matches "server.http-port" and "server.httpPort" but not "serverhttp.port".
+//
+// For elements of slices(e.g., "SERVERS_0_HOST"), the returned value is the whole slice with overridden element,
+// which is copied from the value of sources having lower priority(starting from "lowerIndex").
+func matchOverride(
+	sources []*propertySource, lowerIndex int,
+	namesBySquashed map[string][]string, override *relaxedOverride,
+) (string, interface{}, bool) {
+	for i := len(override.segments); i > 0; i-- {
+		for _, name := range namesBySquashed[strings.Join(override.segments[:i], "")] {
+			if !matchesByParts(name, override.segments[:i]) {
+				continue
+			}
+			if _, declared := fg.GetMetadata(name); override.declaredOnly && !declared {
+				continue
+			}
+
+			if i == len(override.segments) {
+				return name, override.value, true
+			}
+
+			value, ok := lookupSourceValue(sources, lowerIndex, name)
+			if !ok {
+				continue
+			}
+
+			if overriddenValue, ok := overrideElement(value, override.segments[i:], override.value); ok {
+				return name, overriddenValue, true
+			}
+		}
+	}
+
+	return "", nil, false
+}
+
+// Gets value of property from sources having lower priority, the sources having higher priority are the fallback.
+func lookupSourceValue(sources []*propertySource, lowerIndex int, name string) (interface{}, bool) {
+	for _, source := range sources[lowerIndex:] {
+		if source.viper.IsSet(name) {
+			return source.viper.Get(name), true
+		}
+	}
+	for _, source := range sources[:lowerIndex] {
+		if source.viper.IsSet(name) {
+			return source.viper.Get(name), true
+		}
+	}
+
+	return nil, false
+}
+
+// Copies the value(slice or map) with the element replaced by the new value
+func overrideElement(value interface{}, segments []string, newValue string) (interface{}, bool) {
+	if len(segments) == 0 {
+		return newValue, true
+	}
+
+	if slice, ok := value.([]interface{}); ok {
+		index, err := strconv.Atoi(segments[0])
+		if err != nil || index < 0 || index >= len(slice) {
+			return nil, false
+		}
+
+		elem, ok := overrideElement(slice[index], segments[1:], newValue)
+		if !ok {
+			return nil, false
+		}
+
+		copiedSlice := make([]interface{}, len(slice))
+		copy(copiedSlice, slice)
+		copiedSlice[index] = elem
+		return copiedSlice, true
+	}
+
+	valueMap, err := cast.ToStringMapE(value)
+	if err != nil {
+		return nil, false
+	}
+
+	keys := make([]string, 0, len(valueMap))
+	for key := range valueMap {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		/**
+		 * The key "httpPort" is matched by segments [ "http", "port" ]
+		 */
+		matchedLength := matchedSegments(squashedName(key), segments)
+		if matchedLength == 0 {
+			continue
+		}
+		// :~)
+
+		elem, ok := overrideElement(valueMap[key], segments[matchedLength:], newValue)
+		if !ok {
+			continue
+		}
+
+		copiedMap := make(map[string]interface{}, len(valueMap))
+		for k, v := range valueMap {
+			copiedMap[k] = v
+		}
+		copiedMap[key] = elem
+		return copiedMap, true
+	}
+
+	return nil, false
+}
+
+// Checks whether or not every part(separated by ".") of the name is matched by the consecutive segments
+func matchesByParts(name string, segments []string) bool {
+	for _, part := range strings.Split(name, ".") {
+		matchedLength := matchedSegments(squashedName(part), segments)
+		if matchedLength == 0 {
+			return false
+		}
+
+		segments = segments[matchedLength:]
+	}
+
+	return len(segments) == 0
+}
+
+// Gets the number of leading segments which are joined as the squashed name, 0 if there is no match.
+func matchedSegments(squashed string, segments []string) int {
+	joined := ""
+	for i, segment := range segments {
+		joined += segment
+		if joined == squashed {
+			return i + 1
+		}
+		if !strings.HasPrefix(squashed, joined) {
+			break
+		}
+	}
+
+	return 0
+}
+
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,143 @@
+package env
+
+import (
+	"os"
+
+	fg "github.com/mikelue/go-misc/ioc/frangipani"
+	"github.com/mikelue/go-misc/utils"
+	"github.com/spf13/pflag"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Relaxed names", func() {
+	var oldOsArgs []string
+	var envContainer utils.RollbackContainer
+	var testedEnv TrackedEnvironment
+
+	BeforeEach(func() {
+		oldOsArgs = os.Args
+		os.Args = []string {
+			`--lime.config.yaml={ server.timeout: 10s }`,
+			`--server.max-conn=20`,
+			`--servers[1].host=10.1.1.2`,
+			`--not.existing=1`,
+		}
+
+		envContainer = utils.RollbackContainerBuilder.NewEnv(map[string]string {
+			"SERVER_HTTP_PORT": "8081",
+			"SERVER_MAX_CONN": "30",
+			"SERVERS_0_HOST": "10.1.1.1",
+			"CACHE_SIZE": "64",
+			"USER": "root",
+		})
+		envContainer.Setup()
+
+		testedEnv = NewConfigBuilder().
+			Prefix("lime").
+			Priority(CL_ARGS, CL_ENVVAR).
+			Pflags(pflag.NewFlagSet("test-relaxed", pflag.ExitOnError)).
+			EnvVarOverrides("").
+			DefaultWithMap(map[string]interface{} {
+				"server.httpPort": 8080,
+				"server.max-conn": 10,
+				"cache.size": 16,
+				"caches.ize": 32,
+				"user": "lime-user",
+				"servers": []interface{} {
+					map[string]interface{} { "host": "10.0.0.1", "port": 80 },
+					map[string]interface{} { "host": "10.0.0.2", "port": 81 },
+				},
+			}).
+			Build().
+			ParseFlags().
+			Load().(TrackedEnvironment)
+	})
+	AfterEach(func() {
+		os.Args = oldOsArgs
+		envContainer.TearDown()
+	})
+
+	DescribeTable("Overridden values",
+		func(name string, expected interface{}) {
+			Expect(testedEnv.GetProperty(name)).To(BeEquivalentTo(expected))
+		},
+		Entry("By environment variable", "server.http-port", "8081"),
+		Entry("By camel case", "server.httpPort", "8081"),
+		Entry("Arguments have higher priority", "server.max-conn", "20"),
+		Entry("Element of slice(environment variable)", "servers[0].host", "10.1.1.1"),
+		Entry("Element of slice(argument)", "servers[1].host", "10.1.1.2"),
+		Entry("Other field of element is kept", "servers[1].port", "81"),
+		Entry("Not existing property is skipped", "not.existing", ""),
+		Entry("Matched by parts of name", "cache.size", "64"),
+		Entry("Same squashed name is not matched", "caches.ize", "32"),
+		Entry("Environment variable of single segment is skipped", "user", "lime-user"),
+	)
+
+	It("Origins", func() {
+		origins := testedEnv.GetOrigins("server.max-conn")
+
+		Expect(origins).To(HaveLen(3))
+		Expect(*origins[0]).To(Equal(PropertyOrigin{ CL_ARGS, "--server.max-conn", "20", "" }))
+		Expect(*origins[1]).To(Equal(PropertyOrigin{ CL_ENVVAR, "$SERVER_MAX_CONN", "30", "" }))
+	})
+
+	Context("Prefix of environment variables", func() {
+		fg.RegisterMetadata(fg.PropertyMetadata{ Name: "kiwi.http-port", Type: "int" })
+
+		loadEnv := func(configBuilder *ConfigBuilder) fg.Environment {
+			return configBuilder.
+				Prefix("kiwi").
+				Priority(CL_ENVVAR).
+				DefaultWithMap(map[string]interface{} {
+					"kiwi.httpPort": 8080,
+					"java.home": "/usr/java",
+				}).
+				Build().
+				Load()
+		}
+
+		BeforeEach(func() {
+			envContainer.TearDown()
+			envContainer = utils.RollbackContainerBuilder.NewEnv(map[string]string {
+				"KIWI_HTTP_PORT": "8081",
+				"JAVA_HOME": "/opt/java",
+				"APP_JAVA_HOME": "/opt/app-java",
+			})
+			envContainer.Setup()
+		})
+
+		It("Only declared properties are overridden(no prefix is set)", func() {
+			testedEnv := loadEnv(NewConfigBuilder())
+
+			Expect(testedEnv.GetProperty("kiwi.http-port")).To(Equal("8081"))
+			Expect(testedEnv.GetProperty("java.home")).To(Equal("/usr/java"))
+		})
+
+		It("Environment variables having the prefix", func() {
+			testedEnv := loadEnv(NewConfigBuilder().EnvVarOverrides("app"))
+
+			Expect(testedEnv.GetProperty("java.home")).To(Equal("/opt/app-java"))
+			Expect(testedEnv.GetProperty("kiwi.http-port")).To(Equal("8080"))
+		})
+	})
+
+	DescribeTable("nameSegments",
+		func(name string, expected []string) {
+			Expect(nameSegments(name)).To(Equal(expected))
+		},
+		Entry("Environment variable", "SERVERS_0_HOST", []string{ "servers", "0", "host" }),
+		Entry("Indexed", "servers[0].http-port", []string{ "servers", "0", "http", "port" }),
+	)
+
+	DescribeTable("matchesByParts",
+		func(name string, segments []string, expected bool) {
+			Expect(matchesByParts(name, segments)).To(Equal(expected))
+		},
+		Entry("Same parts", "a.bc", []string{ "a", "bc" }, true),
+		Entry("Part of multiple segments", "server.httpport", []string{ "server", "http", "port" }, true),
+		Entry("Different parts", "ab.c", []string{ "a", "bc" }, false),
+		Entry("Segments are not exhausted", "a", []string{ "a", "bc" }, false),
+	)
+})
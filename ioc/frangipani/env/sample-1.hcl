@@ -0,0 +1 @@
+"gdb.key" = "Hc4Wn7Pe"
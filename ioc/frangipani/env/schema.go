@@ -0,0 +1,256 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	fg "github.com/mikelue/go-misc/ioc/frangipani"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// Schema of configurations, which is validated by "ConfigLoader.TryLoad()".
+//
+// See "SchemaBuilder"
+type ConfigSchema interface {
+	// Validates the loaded environment, returns empty slice if there is no violation.
+	Validate(env TrackedEnvironment) []*SchemaViolation
+}
+
+// A violation of schema
+type SchemaViolation struct {
+	// Name of property(could be the prefix for missing properties)
+	Property string
+	Message string
+}
+func (self *SchemaViolation) String() string {
+	return fmt.Sprintf("Property[%s]: %s", self.Property, self.Message)
+}
+
+// The error contains every violation of schemas
+type SchemaError struct {
+	Violations []*SchemaViolation
+}
+func (self *SchemaError) Error() string {
+	messages := make([]string, 0, len(self.Violations) + 1)
+	messages = append(messages, fmt.Sprintf(
+		"Configuration has [%d] violation(s) of schema:", len(self.Violations),
+	))
+
+	for _, violation := range self.Violations {
+		messages = append(messages, "\t" + violation.String())
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+// Registers schemas, which are validated after the environment is loaded.
+//
+// See "ConfigLoader.TryLoad()"
+func (self *ConfigBuilder) Schema(schemas ...ConfigSchema) *ConfigBuilder {
+	self.schemas = append(self.schemas, schemas...)
+	return self
+}
+
+// Method space for building of "ConfigSchema"
+var SchemaBuilder ISchemaBuilder
+
+type ISchemaBuilder int
+// Uses the struct(with tags of "fg" and "validate") as schema of properties with the prefix.
+//
+// The properties are validated by the same rules as "frangipani.BindProperties()".
+//
+// See "Binding of properties" of frangipani.
+func (*ISchemaBuilder) NewByStruct(prefix string, sample interface{}) ConfigSchema {
+	return &structSchema{ prefix: prefix, structType: indirectType(reflect.TypeOf(sample)) }
+}
+// As same as "NewByStruct()", the properties under the prefix which cannot be bound to any field are violations.
+func (*ISchemaBuilder) NewStrictByStruct(prefix string, sample interface{}) ConfigSchema {
+	return &structSchema{ prefix: prefix, structType: indirectType(reflect.TypeOf(sample)), strict: true }
+}
+// Uses JSON Schema to validate properties with the prefix(empty for all of the properties).
+//
+// The flatten keys of properties are turned into nested objects, e.g., "db.port" is turned into "{ db: { port: <value> } }".
+//
+// The "additionalProperties: false" could be used to reject unknown keys.
+//
+// See: https://json-schema.org/
+func (*ISchemaBuilder) NewByJsonSchema(prefix string, schema string) (ConfigSchema, error) {
+	loadedSchema, err := gojsonschema.NewSchema(gojsonschema.NewStringLoader(schema))
+	if err != nil {
+		return nil, fmt.Errorf("Unable to load JSON Schema: %w", err)
+	}
+
+	return &jsonSchema{ prefix, loadedSchema }, nil
+}
+
+// Validates the environment by every schema
+func validateSchemas(env TrackedEnvironment, schemas []ConfigSchema) error {
+	violations := make([]*SchemaViolation, 0)
+	for _, schema := range schemas {
+		violations = append(violations, schema.Validate(env)...)
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	return &SchemaError{ violations }
+}
+
+type structSchema struct {
+	prefix string
+	structType reflect.Type
+	strict bool
+}
+func (self *structSchema) Validate(env TrackedEnvironment) []*SchemaViolation {
+	violations := make([]*SchemaViolation, 0)
+
+	/**
+	 * Uses the violations of binding properties
+	 */
+	err := fg.BindProperties(env, self.prefix, reflect.New(self.structType).Interface())
+
+	var bindingErr *fg.BindingError
+	if errors.As(err, &bindingErr) {
+		for _, fieldErr := range bindingErr.FieldErrors {
+			violations = append(violations, &SchemaViolation{ fieldErr.Property, fieldErr.Err.Error() })
+		}
+	} else if err != nil {
+		violations = append(violations, &SchemaViolation{ self.prefix, err.Error() })
+	}
+	// :~)
+
+	if !self.strict {
+		return violations
+	}
+
+	/**
+	 * Checks the unknown keys under the prefix
+	 */
+	exactNames, openPrefixes := make(map[string]bool), make([]string, 0)
+	collectNamesOfStruct(self.prefix, self.structType, exactNames, &openPrefixes)
+
+	for _, name := range env.GetPropertyNames() {
+		if !strings.HasPrefix(name, self.prefix + ".") ||
+			exactNames[name] || hasAnyPrefix(name, openPrefixes) {
+			continue
+		}
+
+		violations = append(violations, &SchemaViolation{ name, "unknown property" })
+	}
+	// :~)
+
+	return violations
+}
+
+// Collects the names of properties(lower case) could be bound to the struct.
+//
+// The fields of map, slice, or interface{} take any property under their names.
+func collectNamesOfStruct(prefix string, structType reflect.Type, exactNames map[string]bool, openPrefixes *[]string) {
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		tag := field.Tag.Get(fg.TAG_PROPERTY)
+		if tag == "-" {
+			continue
+		}
+
+		propertyName := field.Name
+		if tag != "" {
+			propertyName = strings.SplitN(tag, fg.PLACEHOLDER_VALUE_SEPARATOR, 2)[0]
+		}
+		propertyName = strings.ToLower(fmt.Sprintf("%s.%s", prefix, propertyName))
+
+		fieldType := indirectType(field.Type)
+		switch {
+		case fieldType.Kind() == reflect.Struct && fieldType != reflect.TypeOf(time.Time{}):
+			collectNamesOfStruct(propertyName, fieldType, exactNames, openPrefixes)
+		case fieldType.Kind() == reflect.Map, fieldType.Kind() == reflect.Slice,
+			fieldType.Kind() == reflect.Array, fieldType.Kind() == reflect.Interface:
+			exactNames[propertyName] = true
+			*openPrefixes = append(*openPrefixes, propertyName + ".")
+		default:
+			exactNames[propertyName] = true
+		}
+	}
+}
+
+func hasAnyPrefix(name string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return t
+}
+
+type jsonSchema struct {
+	prefix string
+	schema *gojsonschema.Schema
+}
+func (self *jsonSchema) Validate(env TrackedEnvironment) []*SchemaViolation {
+	result, err := self.schema.Validate(
+		gojsonschema.NewGoLoader(nestedProperties(env, self.prefix)),
+	)
+	if err != nil {
+		return []*SchemaViolation{ { self.prefix, err.Error() } }
+	}
+
+	violations := make([]*SchemaViolation, 0, len(result.Errors()))
+	for _, resultErr := range result.Errors() {
+		property := self.prefix
+		if field := resultErr.Field(); field != gojsonschema.STRING_CONTEXT_ROOT {
+			property = strings.TrimPrefix(fmt.Sprintf("%s.%s", self.prefix, field), ".")
+		}
+
+		violations = append(violations, &SchemaViolation{ property, resultErr.Description() })
+	}
+
+	return violations
+}
+
+// Turns the flatten properties(with the prefix) into nested maps
+func nestedProperties(env TrackedEnvironment, prefix string) map[string]interface{} {
+	nestedProps := make(map[string]interface{})
+
+	for _, name := range env.GetPropertyNames() {
+		relativeName := name
+		if prefix != "" {
+			if !strings.HasPrefix(name, prefix + ".") {
+				continue
+			}
+			relativeName = name[len(prefix) + 1:]
+		}
+
+		currentMap := nestedProps
+		keys := strings.Split(relativeName, ".")
+		for _, key := range keys[:len(keys) - 1] {
+			childMap, ok := currentMap[key].(map[string]interface{})
+			if !ok {
+				childMap = make(map[string]interface{})
+				currentMap[key] = childMap
+			}
+			currentMap = childMap
+		}
+
+		currentMap[keys[len(keys) - 1]] = env.Typed().Get(name)
+	}
+
+	return nestedProps
+}
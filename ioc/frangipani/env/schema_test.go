@@ -0,0 +1,130 @@
+package env
+
+import (
+	"errors"
+	"os"
+
+	"github.com/spf13/pflag"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Schema of configurations", func() {
+	var oldOsArgs []string
+
+	BeforeEach(func() {
+		oldOsArgs = os.Args
+		os.Args = []string {
+			`--fig.config.yaml={ srv.port: not-a-port, srv.workers: 0, srv.tls.cert: /etc/cert.pem, srv.tags.t1: v1, srv.unknown-key: 1 }`,
+		}
+	})
+	AfterEach(func() {
+		os.Args = oldOsArgs
+	})
+
+	loadBySchema := func(schemas ...ConfigSchema) (TrackedEnvironment, error) {
+		env, err := NewConfigBuilder().
+			Prefix("fig").
+			Priority(CL_ARGS).
+			Pflags(pflag.NewFlagSet("test-schema", pflag.ExitOnError)).
+			Schema(schemas...).
+			Build().
+			ParseFlags().
+			TryLoad()
+
+		if env == nil {
+			return nil, err
+		}
+		return env.(TrackedEnvironment), err
+	}
+	violatedProperties := func(err error) []string {
+		var schemaErr *SchemaError
+		Expect(errors.As(err, &schemaErr)).To(BeTrue())
+
+		properties := make([]string, 0, len(schemaErr.Violations))
+		for _, violation := range schemaErr.Violations {
+			properties = append(properties, violation.Property)
+		}
+		return properties
+	}
+
+	It("By struct", func() {
+		_, err := loadBySchema(SchemaBuilder.NewByStruct("srv", &sampleServerConfig{}))
+
+		Expect(violatedProperties(err)).To(ConsistOf(
+			"srv.host", "srv.port", "srv.workers",
+		))
+		Expect(err).To(MatchError(MatchRegexp(`Property\[srv.host\]: property is required`)))
+	})
+
+	It("By struct(strict)", func() {
+		_, err := loadBySchema(SchemaBuilder.NewStrictByStruct("srv", sampleServerConfig{}))
+
+		Expect(violatedProperties(err)).To(ConsistOf(
+			"srv.host", "srv.port", "srv.workers", "srv.unknown-key",
+		))
+	})
+
+	It("By JSON Schema", func() {
+		schema, err := SchemaBuilder.NewByJsonSchema("srv", `{
+			"type": "object",
+			"required": [ "host" ],
+			"properties": {
+				"port": { "type": "integer" },
+				"workers": { "type": "integer", "minimum": 1 },
+				"tls": { "type": "object" },
+				"tags": { "type": "object" }
+			},
+			"additionalProperties": false
+		}`)
+		Expect(err).To(Succeed())
+
+		_, err = loadBySchema(schema)
+
+		Expect(violatedProperties(err)).To(ConsistOf(
+			"srv", "srv.port", "srv.workers", "srv",
+		))
+		Expect(err).To(MatchError(And(
+			MatchRegexp(`host is required`),
+			MatchRegexp(`unknown-key is not allowed`),
+		)))
+	})
+
+	It("Malformed JSON Schema", func() {
+		_, err := SchemaBuilder.NewByJsonSchema("", `{ "type": 10 }`)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("Load() still returns the environment", func() {
+		testedEnv := NewConfigBuilder().
+			Prefix("fig").
+			Priority(CL_ARGS).
+			Pflags(pflag.NewFlagSet("test-schema-load", pflag.ExitOnError)).
+			Schema(SchemaBuilder.NewByStruct("srv", &sampleServerConfig{})).
+			Build().
+			ParseFlags().
+			Load()
+
+		Expect(testedEnv.GetProperty("srv.tls.cert")).To(Equal("/etc/cert.pem"))
+	})
+
+	It("No violation", func() {
+		testedEnv, err := loadBySchema(SchemaBuilder.NewByStruct("srv.tls", &struct {
+			Cert string `fg:"cert" validate:"required"`
+		}{}))
+
+		Expect(err).To(Succeed())
+		Expect(testedEnv.GetProperty("srv.tls.cert")).To(Equal("/etc/cert.pem"))
+	})
+})
+
+type sampleServerConfig struct {
+	Host string `fg:"host" validate:"required"`
+	Port int `fg:"port:8080"`
+	Workers int `fg:"workers:4" validate:"min=1"`
+	Tls struct {
+		Cert string `fg:"cert"`
+	} `fg:"tls"`
+	Tags map[string]string `fg:"tags"`
+}
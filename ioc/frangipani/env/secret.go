@@ -0,0 +1,190 @@
+package env
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	fg "github.com/mikelue/go-misc/ioc/frangipani"
+)
+
+const (
+	// The prefix of encrypted value, e.g., "ENC(c2FtcGxl...)"
+	ENC_PREFIX = "ENC("
+	// The suffix of encrypted value
+	ENC_SUFFIX = ")"
+
+	// The keys(or prefixes of keys) of properties to be masked, e.g., "fgapp.secret.keys=db.password,oauth"
+	PROP_SECRET_KEYS = ".secret.keys"
+)
+
+// Decrypts the content of "ENC(<content>)" in values of properties.
+//
+// See "ConfigBuilder.Decryptor()"
+type Decryptor interface {
+	Decrypt(cipherText string) (string, error)
+}
+
+// Function version of "Decryptor"
+type DecryptorFunc func(cipherText string) (string, error)
+func (self DecryptorFunc) Decrypt(cipherText string) (string, error) {
+	return self(cipherText)
+}
+
+// Sets the decryptor, which decrypts the values of "ENC(<cipher text>)" while loading.
+//
+// The keys having encrypted values are treated as secret ones,
+// and the values which cannot be decrypted are reported as causes of "*LoadingError"(by "ConfigLoader.TryLoad()").
+//
+// See "CipherBuilder"
+func (self *ConfigBuilder) Decryptor(decryptor Decryptor) *ConfigBuilder {
+	self.decryptor = decryptor
+	return self
+}
+
+// Method space for building of built-in ciphers
+var CipherBuilder ICipherBuilder
+
+type ICipherBuilder int
+// Constructs AES-GCM cipher by the key(must be 16, 24, or 32 bytes)
+func (*ICipherBuilder) NewAesGcm(key []byte) (*AesGcmCipher, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AesGcmCipher{ aead }, nil
+}
+// Constructs AES-GCM cipher by the key(as base64) from the environment variable
+func (self *ICipherBuilder) NewAesGcmByEnv(envVarName string) (*AesGcmCipher, error) {
+	encodedKey, ok := os.LookupEnv(envVarName)
+	if !ok {
+		return nil, fmt.Errorf("Environment variable for key is not existing: $%s", envVarName)
+	}
+
+	return self.newAesGcmByEncodedKey(encodedKey)
+}
+// Constructs AES-GCM cipher by the key(as base64) from the file
+func (self *ICipherBuilder) NewAesGcmByKeyFile(file string) (*AesGcmCipher, error) {
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read file of key[%s]: %w", file, err)
+	}
+
+	return self.newAesGcmByEncodedKey(string(content))
+}
+func (self *ICipherBuilder) newAesGcmByEncodedKey(encodedKey string) (*AesGcmCipher, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encodedKey))
+	if err != nil {
+		return nil, fmt.Errorf("Key is not valid base64: %w", err)
+	}
+
+	return self.NewAesGcm(key)
+}
+
+// The cipher text is base64 of "<nonce><encrypted content>"
+type AesGcmCipher struct {
+	aead cipher.AEAD
+}
+// Encrypts the text as "ENC(<cipher text>)"
+func (self *AesGcmCipher) Encrypt(plainText string) (string, error) {
+	nonce := make([]byte, self.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+
+	sealed := self.aead.Seal(nonce, nonce, []byte(plainText), nil)
+	return ENC_PREFIX + base64.StdEncoding.EncodeToString(sealed) + ENC_SUFFIX, nil
+}
+func (self *AesGcmCipher) Decrypt(cipherText string) (string, error) {
+	sealed, err := base64.StdEncoding.DecodeString(cipherText)
+	if err != nil {
+		return "", fmt.Errorf("Cipher text is not valid base64: %w", err)
+	}
+
+	nonceSize := self.aead.NonceSize()
+	if len(sealed) < nonceSize {
+		return "", fmt.Errorf("Cipher text is too short")
+	}
+
+	plainText, err := self.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], nil)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plainText), nil
+}
+
+// Gets the value of property as list, the value of string is separated by comma
+func getStringList(env fg.Environment, name string) []string {
+	value, ok := env.Typed().Get(name).(string)
+	if !ok {
+		return env.Typed().GetStringSlice(name)
+	}
+
+	values := make([]string, 0)
+	for _, element := range strings.Split(value, ",") {
+		if element = strings.TrimSpace(element); element != "" {
+			values = append(values, element)
+		}
+	}
+
+	return values
+}
+
+// Gets the content of "ENC(<content>)"
+func encryptedContent(value string) (string, bool) {
+	if strings.HasPrefix(value, ENC_PREFIX) && strings.HasSuffix(value, ENC_SUFFIX) {
+		return value[len(ENC_PREFIX):len(value) - len(ENC_SUFFIX)], true
+	}
+
+	return "", false
+}
+
+// Decrypts the value(string or elements of slice), the "bool" is true if the value is encrypted.
+func decryptValue(decryptor Decryptor, value interface{}) (interface{}, bool, error) {
+	switch typedValue := value.(type) {
+	case string:
+		content, ok := encryptedContent(typedValue)
+		if !ok {
+			return value, false, nil
+		}
+
+		if decryptor == nil {
+			return value, true, nil
+		}
+
+		plainText, err := decryptor.Decrypt(content)
+		if err != nil {
+			return value, true, err
+		}
+		return plainText, true, nil
+	case []interface{}:
+		decryptedValues := make([]interface{}, len(typedValue))
+		anyEncrypted := false
+
+		for i, element := range typedValue {
+			decrypted, encrypted, err := decryptValue(decryptor, element)
+			if err != nil {
+				return value, true, err
+			}
+
+			decryptedValues[i] = decrypted
+			anyEncrypted = anyEncrypted || encrypted
+		}
+		return decryptedValues, anyEncrypted, nil
+	}
+
+	return value, false, nil
+}
@@ -0,0 +1,167 @@
+package env
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mikelue/go-misc/utils"
+	"github.com/spf13/pflag"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Secret values", func() {
+	sampleKey := []byte("0123456789abcdef0123456789abcdef")
+
+	Context("AesGcmCipher", func() {
+		It("Encrypts and decrypts", func() {
+			testedCipher, _ := CipherBuilder.NewAesGcm(sampleKey)
+
+			encrypted, err := testedCipher.Encrypt("mY6hFr2w")
+			Expect(err).To(Succeed())
+
+			content, ok := encryptedContent(encrypted)
+			Expect(ok).To(BeTrue())
+
+			decrypted, err := testedCipher.Decrypt(content)
+			Expect(err).To(Succeed())
+			Expect(decrypted).To(Equal("mY6hFr2w"))
+		})
+
+		It("Decrypts with wrong key", func() {
+			encryptingCipher, _ := CipherBuilder.NewAesGcm(sampleKey)
+			encrypted, _ := encryptingCipher.Encrypt("mY6hFr2w")
+			content, _ := encryptedContent(encrypted)
+
+			testedCipher, _ := CipherBuilder.NewAesGcm([]byte("fedcba9876543210"))
+			_, err := testedCipher.Decrypt(content)
+			Expect(err).To(HaveOccurred())
+		})
+
+		DescribeTable("Invalid key",
+			func(buildCipher func() (*AesGcmCipher, error), expectedErr string) {
+				_, err := buildCipher()
+
+				Expect(err).To(MatchError(MatchRegexp(expectedErr)))
+			},
+			Entry("Size of key", func() (*AesGcmCipher, error) {
+				return CipherBuilder.NewAesGcm([]byte("short"))
+			}, `invalid key size`),
+			Entry("Not existing env var", func() (*AesGcmCipher, error) {
+				return CipherBuilder.NewAesGcmByEnv("NOT_EXISTING_KEY_OF_FG")
+			}, `\$NOT_EXISTING_KEY_OF_FG`),
+			Entry("Not existing file", func() (*AesGcmCipher, error) {
+				return CipherBuilder.NewAesGcmByKeyFile("/not-existing/fg.key")
+			}, `Unable to read file of key`),
+		)
+	})
+
+	DescribeTable("decryptValue",
+		func(value interface{}, expected interface{}, expectedEncrypted bool) {
+			decryptor := DecryptorFunc(func(cipherText string) (string, error) {
+				return strings.ToUpper(cipherText), nil
+			})
+
+			testedValue, encrypted, err := decryptValue(decryptor, value)
+
+			Expect(err).To(Succeed())
+			Expect(encrypted).To(Equal(expectedEncrypted))
+			Expect(testedValue).To(Equal(expected))
+		},
+		Entry("Encrypted", "ENC(abc)", "ABC", true),
+		Entry("Not encrypted", "abc", "abc", false),
+		Entry("Not string", 20, 20, false),
+		Entry("Slice", []interface{}{ "ENC(a1)", "a2" }, []interface{}{ "A1", "a2" }, true),
+	)
+
+	Context("Loading with decryptor", func() {
+		var oldOsArgs []string
+		var envContainer utils.RollbackContainer
+		var testedEnv TrackedEnvironment
+
+		BeforeEach(func() {
+			encryptingCipher, _ := CipherBuilder.NewAesGcm(sampleKey)
+			encryptedPassword, _ := encryptingCipher.Encrypt("xP3nVq7T")
+
+			oldOsArgs = os.Args
+			os.Args = []string {
+				fmt.Sprintf(
+					`--plum.config.yaml={ db.user: plum, db.phrase: "%s", db.url: "pg://${db.phrase}@10.1.1.1", oauth.client: c01 }`,
+					encryptedPassword,
+				),
+				`--plum.config.json={ "plum.secret.keys": "db.user, OAuth" }`,
+			}
+
+			envContainer = utils.RollbackContainerBuilder.NewEnv(map[string]string {
+				"PLUM_SECRET_KEY": base64.StdEncoding.EncodeToString(sampleKey),
+				"PLUM_CONFIG_YAML": `{ db.url: "pg://${db.phrase}@10.1.1.2" }`,
+			})
+			envContainer.Setup()
+
+			decryptor, err := CipherBuilder.NewAesGcmByEnv("PLUM_SECRET_KEY")
+			Expect(err).To(Succeed())
+
+			testedEnv = NewConfigBuilder().
+				Prefix("plum").
+				Priority(CL_ARGS, CL_ENVVAR).
+				Pflags(pflag.NewFlagSet("test-decryptor", pflag.ExitOnError)).
+				Decryptor(decryptor).
+				Build().
+				ParseFlags().
+				Load().(TrackedEnvironment)
+		})
+		AfterEach(func() {
+			os.Args = oldOsArgs
+			envContainer.TearDown()
+		})
+
+		It("Decrypted value", func() {
+			Expect(testedEnv.GetProperty("db.phrase")).To(Equal("xP3nVq7T"))
+			Expect(testedEnv.GetOrigins("db.phrase")[0].Value).
+				To(HavePrefix(ENC_PREFIX))
+		})
+
+		It("Masked values", func() {
+			var dumpedText strings.Builder
+			Expect(testedEnv.Dump(&dumpedText)).To(Succeed())
+
+			Expect(dumpedText.String()).To(And(
+				ContainSubstring("db.phrase = " + MASKED_VALUE),
+				ContainSubstring("db.user = " + MASKED_VALUE),
+				ContainSubstring("oauth.client = " + MASKED_VALUE),
+				ContainSubstring("db.url = " + MASKED_VALUE),
+				ContainSubstring("(shadowed) " + MASKED_VALUE),
+				Not(ContainSubstring("xP3nVq7T")),
+				Not(ContainSubstring("10.1.1.2")),
+			))
+			Expect(testedEnv.GetProperty("db.url")).To(Equal("pg://xP3nVq7T@10.1.1.1"))
+		})
+	})
+
+	It("Errors of decryption", func() {
+		oldOsArgs := os.Args
+		defer func() { os.Args = oldOsArgs }()
+		os.Args = []string {
+			`--prune.config.yaml={ db.phrase: "ENC(bm90LWVuY3J5cHRlZA==)" }`,
+		}
+
+		decryptor, _ := CipherBuilder.NewAesGcm(sampleKey)
+		_, err := NewConfigBuilder().
+			Prefix("prune").
+			Priority(CL_ARGS).
+			Pflags(pflag.NewFlagSet("test-decryptor-error", pflag.ExitOnError)).
+			Decryptor(decryptor).
+			Build().
+			ParseFlags().
+			TryLoad()
+
+		var loadingErr *LoadingError
+		Expect(errors.As(err, &loadingErr)).To(BeTrue())
+		Expect(loadingErr.Causes).To(HaveLen(1))
+		Expect(err).To(MatchError(ContainSubstring("Unable to decrypt property[db.phrase]")))
+	})
+})
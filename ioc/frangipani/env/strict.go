@@ -0,0 +1,59 @@
+package env
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Enables the strict mode, "ConfigLoader.TryLoad()" returns "*LoadingError" if any of
+// explicitly requested sources cannot be loaded:
+//
+//   1. The files of "<prefix>.config.files" are not existing or malformed
+//   2. The content of "--<prefix>.config.yaml"(and other formats, including environment variables) is malformed
+//
+// The discovered files(by default names) of "CL_XDG", "CL_PWD", and "CL_CMDDIR" are still skipped silently.
+//
+// The values of "ENC(...)" which cannot be decrypted are reported by "ConfigLoader.TryLoad()" whether or not in strict mode.
+func (self *ConfigBuilder) Strict() *ConfigBuilder {
+	self.strict = true
+	return self
+}
+
+// The error of loading, which contains every cause of failed sources.
+type LoadingError struct {
+	Causes []error
+}
+func (self *LoadingError) Error() string {
+	messages := make([]string, 0, len(self.Causes) + 1)
+	messages = append(messages, fmt.Sprintf(
+		"Loading of configuration has [%d] error(s):", len(self.Causes),
+	))
+
+	for _, cause := range self.Causes {
+		messages = append(messages, "\t" + cause.Error())
+	}
+
+	return strings.Join(messages, "\n")
+}
+// Gets the first cause(use "LoadingError.Causes" for every cause)
+func (self *LoadingError) Unwrap() error {
+	return self.Causes[0]
+}
+
+// Implemented by workers which keep errors of explicitly requested sources
+type errorReporter interface {
+	loadingErrors() []error
+}
+
+// Collects errors of workers(ordered by priority of sources)
+func (self *configLoaderImpl) collectLoadingErrors() []error {
+	causes := make([]error, 0)
+
+	for _, source := range self.sources {
+		if reporter, ok := self.workers[source].(errorReporter); ok {
+			causes = append(causes, reporter.loadingErrors()...)
+		}
+	}
+
+	return causes
+}
@@ -0,0 +1,75 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/mikelue/go-misc/utils"
+	"github.com/spf13/pflag"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Strict mode", func() {
+	var oldOsArgs []string
+	var envContainer utils.RollbackContainer
+
+	BeforeEach(func() {
+		oldOsArgs = os.Args
+		os.Args = []string {
+			`--date.config.yaml={ db.host: [10.1.1.1 }`,
+			fmt.Sprintf(
+				`--date.config.files=%s/split-peas-config.yaml,%s/not-existing-config.yaml`,
+				currentSrcDir, currentSrcDir,
+			),
+		}
+
+		envContainer = utils.RollbackContainerBuilder.NewEnv(map[string]string {
+			"DATE_CONFIG_JSON": `{ "db.port": }`,
+		})
+		envContainer.Setup()
+	})
+	AfterEach(func() {
+		os.Args = oldOsArgs
+		envContainer.TearDown()
+	})
+
+	newBuilder := func() *ConfigBuilder {
+		return NewConfigBuilder().
+			Prefix("date").
+			Priority(CL_ARGS, CL_ENVVAR, CL_CONFIG_FILE, CL_PWD).
+			Pflags(pflag.NewFlagSet("test-strict", pflag.ExitOnError))
+	}
+
+	It("Errors of requested sources", func() {
+		testedEnv, err := newBuilder().
+			Strict().
+			Build().
+			ParseFlags().
+			TryLoad()
+
+		Expect(testedEnv).To(BeNil())
+
+		var loadingErr *LoadingError
+		Expect(errors.As(err, &loadingErr)).To(BeTrue())
+		Expect(loadingErr.Causes).To(HaveLen(3))
+		Expect(err).To(MatchError(And(
+			MatchRegexp(`--date.config.yaml`),
+			MatchRegexp(`\$DATE_CONFIG_JSON`),
+			MatchRegexp(`not-existing-config.yaml`),
+		)))
+		Expect(os.IsNotExist(loadingErr.Causes[2])).To(BeTrue())
+	})
+
+	It("Not strict", func() {
+		testedEnv, err := newBuilder().
+			Build().
+			ParseFlags().
+			TryLoad()
+
+		Expect(err).To(Succeed())
+		Expect(testedEnv.GetProperty("db.sample.host")).To(Equal("192.186.21.50"))
+	})
+})
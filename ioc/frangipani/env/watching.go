@@ -0,0 +1,372 @@
+package env
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	fg "github.com/mikelue/go-misc/ioc/frangipani"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// The delay before reloading, which is used to merge bursting events of files(e.g., saving by editors)
+var reloadDelay = 100 * time.Millisecond
+
+// The listener gets called after the environment has been reloaded.
+//
+// The listeners are called sequentially by the goroutine of reloading.
+type ChangeListener func(event *ChangeEvent)
+
+// The event of reloading of environment
+type ChangeEvent struct {
+	// The changed properties(sorted by name)
+	Changes []*PropertyChange
+	// Viable if the reloading is failed(including violations of schemas), the previous snapshot of environment is kept.
+	Err error
+}
+
+// The change of a property
+type PropertyChange struct {
+	Name string
+	// "nil" if the property is added
+	OldValue interface{}
+	// "nil" if the property is removed
+	NewValue interface{}
+}
+
+// The "Environment" loaded by "ConfigLoader.Load()" if "ConfigBuilder.Watch()" is used.
+//
+// Every method gets the values of current snapshot, which is replaced atomically after reloading.
+//
+//   watchedEnv := env.(WatchedEnvironment)
+//   defer watchedEnv.Close()
+type WatchedEnvironment interface {
+	TrackedEnvironment
+
+	// Adds a listener for changes of properties
+	AddListener(listener ChangeListener)
+	// Reloads the environment immediately
+	//
+	// The error is viable if any of files cannot be parsed(or violates schemas), the previous snapshot is kept.
+	Reload() error
+	// Stops the watching of files
+	Close() error
+}
+
+// Enables the watching of files(the default files of "CL_XDG", "CL_PWD", "CL_CMDDIR" and "<prefix>.config.files"),
+// the environment would be reloaded if any of the files is changed.
+//
+// The loaded environment is a "WatchedEnvironment".
+func (self *ConfigBuilder) Watch(listeners ...ChangeListener) *ConfigBuilder {
+	self.watching = true
+	self.listeners = append(self.listeners, listeners...)
+	return self
+}
+
+// Gets absolute paths of files which could be loaded by workers
+func (self *configLoaderImpl) watchedFiles(profiles []string) []string {
+	files := make([]string, 0)
+
+	for _, source := range self.sources {
+		worker, ok := self.workers[source].(*filesWorker)
+		if !ok {
+			continue
+		}
+
+		for _, file := range worker.candidateFiles(profiles...) {
+			absFile, err := filepath.Abs(file)
+			if err != nil {
+				configLogger.Warnf("Unable to get absolute path of file[%s]: %v", file, err)
+				continue
+			}
+
+			files = append(files, absFile)
+		}
+	}
+
+	return files
+}
+
+func newWatchedEnv(loader *configLoaderImpl, trackedEnv *trackedEnvImpl) *watchedEnvImpl {
+	newEnv := &watchedEnvImpl {
+		loader: loader,
+		listeners: append([]ChangeListener(nil), loader.listeners...),
+		watchedDirs: make(map[string]bool),
+	}
+	newEnv.current.Store(trackedEnv)
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		configLogger.Errorf("Unable to watch files of configuration: %v", err)
+		return newEnv
+	}
+
+	newEnv.watcher = watcher
+	newEnv.refreshWatchedFiles(trackedEnv)
+
+	go newEnv.watch()
+	return newEnv
+}
+
+type watchedEnvImpl struct {
+	loader *configLoaderImpl
+	current atomic.Value
+
+	reloadLock sync.Mutex
+	listenersLock sync.Mutex
+	filesLock sync.RWMutex
+
+	listeners []ChangeListener
+	watcher *fsnotify.Watcher
+	watchedFiles map[string]bool
+	watchedDirs map[string]bool
+}
+func (self *watchedEnvImpl) Typed() fg.TypedR {
+	return self.snapshot().Typed()
+}
+func (self *watchedEnvImpl) RequiredTyped() fg.RequiredTypedR {
+	return self.snapshot().RequiredTyped()
+}
+func (self *watchedEnvImpl) ContainsProperty(name string) bool {
+	return self.snapshot().ContainsProperty(name)
+}
+func (self *watchedEnvImpl) GetProperty(name string) string {
+	return self.snapshot().GetProperty(name)
+}
+func (self *watchedEnvImpl) GetRequiredProperty(name string) (string, error) {
+	return self.snapshot().GetRequiredProperty(name)
+}
+func (self *watchedEnvImpl) AcceptsProfiles(profiles fg.Profiles) bool {
+	return self.snapshot().AcceptsProfiles(profiles)
+}
+func (self *watchedEnvImpl) GetActiveProfiles() []string {
+	return self.snapshot().GetActiveProfiles()
+}
+func (self *watchedEnvImpl) AllProperties() map[string]interface{} {
+	return self.snapshot().AllProperties()
+}
+func (self *watchedEnvImpl) GetOrigins(name string) []*PropertyOrigin {
+	return self.snapshot().GetOrigins(name)
+}
+func (self *watchedEnvImpl) GetPropertyNames() []string {
+	return self.snapshot().GetPropertyNames()
+}
+func (self *watchedEnvImpl) Dump(writer io.Writer) error {
+	return self.snapshot().Dump(writer)
+}
+func (self *watchedEnvImpl) AddListener(listener ChangeListener) {
+	self.listenersLock.Lock()
+	defer self.listenersLock.Unlock()
+
+	self.listeners = append(self.listeners, listener)
+}
+func (self *watchedEnvImpl) Reload() error {
+	self.reloadLock.Lock()
+	defer self.reloadLock.Unlock()
+
+	oldEnv := self.snapshot()
+
+	/**
+	 * Keeps the previous snapshot if any of the files cannot be parsed
+	 */
+	if err := self.checkFiles(); err != nil {
+		configLogger.Errorf("Reloading of configuration is failed(previous one is kept): %v", err)
+		self.fireEvent(&ChangeEvent{ Err: err })
+		return err
+	}
+	// :~)
+
+	/**
+	 * Loads the environment by a new loader(the workers cache loaded vipers)
+	 */
+	copiedBuilder := *self.loader.ConfigBuilder
+	newLoader := &configLoaderImpl {
+		ConfigBuilder: &copiedBuilder,
+		argsConfig: self.loader.argsConfig,
+	}
+	newEnv, err := newLoader.loadAndValidate()
+	if err != nil {
+		configLogger.Errorf("Reloaded configuration is invalid(previous one is kept): %v", err)
+		self.fireEvent(&ChangeEvent{ Err: err })
+		return err
+	}
+	// :~)
+
+	self.loader = newLoader
+	self.current.Store(newEnv)
+	self.refreshWatchedFiles(newEnv)
+
+	if changes := diffProperties(oldEnv, newEnv); len(changes) > 0 {
+		configLogger.Infof("Configuration is reloaded with [%d] changed properties", len(changes))
+		self.fireEvent(&ChangeEvent{ Changes: changes })
+	}
+
+	return nil
+}
+func (self *watchedEnvImpl) Close() error {
+	if self.watcher == nil {
+		return nil
+	}
+
+	return self.watcher.Close()
+}
+func (self *watchedEnvImpl) snapshot() *trackedEnvImpl {
+	return self.current.Load().(*trackedEnvImpl)
+}
+func (self *watchedEnvImpl) watch() {
+	var reloadTimer *time.Timer
+	defer func() {
+		if reloadTimer != nil {
+			reloadTimer.Stop()
+		}
+	}()
+
+	for {
+		select {
+		case event, ok := <-self.watcher.Events:
+			if !ok {
+				return
+			}
+			if !self.isWatchedFile(event.Name) {
+				continue
+			}
+
+			configLogger.Debugf("File of configuration is changed: %v", event)
+
+			if reloadTimer != nil {
+				reloadTimer.Stop()
+			}
+			reloadTimer = time.AfterFunc(reloadDelay, func() {
+				self.Reload()
+			})
+		case err, ok := <-self.watcher.Errors:
+			if !ok {
+				return
+			}
+
+			configLogger.Warnf("Watching of files has error: %v", err)
+		}
+	}
+}
+func (self *watchedEnvImpl) isWatchedFile(name string) bool {
+	self.filesLock.RLock()
+	defer self.filesLock.RUnlock()
+
+	return self.watchedFiles[filepath.Clean(name)]
+}
+// Watches the directories of files, so the creating(or renaming) of files could be noticed.
+func (self *watchedEnvImpl) refreshWatchedFiles(trackedEnv *trackedEnvImpl) {
+	if self.watcher == nil {
+		return
+	}
+
+	files := self.loader.watchedFiles(trackedEnv.GetActiveProfiles())
+
+	// The loaded files(including imported ones)
+	for _, source := range trackedEnv.sources {
+		if file := source.viper.ConfigFileUsed(); file != "" {
+			if absFile, err := filepath.Abs(file); err == nil {
+				files = append(files, absFile)
+			}
+		}
+	}
+
+	newFiles := make(map[string]bool, len(files))
+	newDirs := make(map[string]bool)
+	for _, file := range files {
+		newFiles[file] = true
+
+		dir := filepath.Dir(file)
+		if stat, err := os.Stat(dir); err == nil && stat.IsDir() {
+			newDirs[dir] = true
+		}
+	}
+
+	self.filesLock.Lock()
+	defer self.filesLock.Unlock()
+
+	for dir := range newDirs {
+		if self.watchedDirs[dir] {
+			continue
+		}
+
+		if err := self.watcher.Add(dir); err != nil {
+			configLogger.Warnf("Unable to watch directory[%s]: %v", dir, err)
+			delete(newDirs, dir)
+		}
+	}
+	for dir := range self.watchedDirs {
+		if !newDirs[dir] {
+			self.watcher.Remove(dir)
+		}
+	}
+
+	self.watchedFiles = newFiles
+	self.watchedDirs = newDirs
+}
+// Parses the existing files, which are watched
+func (self *watchedEnvImpl) checkFiles() error {
+	self.filesLock.RLock()
+	defer self.filesLock.RUnlock()
+
+	for file := range self.watchedFiles {
+		if _, err := os.Stat(file); err != nil {
+			continue
+		}
+
+		if _, err := readInByFileE(file); err != nil {
+			return fmt.Errorf("Unable to parse file[%s]: %w", file, err)
+		}
+	}
+
+	return nil
+}
+func (self *watchedEnvImpl) fireEvent(event *ChangeEvent) {
+	self.listenersLock.Lock()
+	listeners := append([]ChangeListener(nil), self.listeners...)
+	self.listenersLock.Unlock()
+
+	for _, listener := range listeners {
+		listener(event)
+	}
+}
+
+// Compares the effective values of properties between environments
+func diffProperties(oldEnv *trackedEnvImpl, newEnv *trackedEnvImpl) []*PropertyChange {
+	allNames := make(map[string]bool)
+	for _, name := range oldEnv.GetPropertyNames() {
+		allNames[name] = true
+	}
+	for _, name := range newEnv.GetPropertyNames() {
+		allNames[name] = true
+	}
+
+	sortedNames := make([]string, 0, len(allNames))
+	for name := range allNames {
+		sortedNames = append(sortedNames, name)
+	}
+	sort.Strings(sortedNames)
+
+	changes := make([]*PropertyChange, 0)
+	for _, name := range sortedNames {
+		oldValue := oldEnv.Typed().Get(name)
+		newValue := newEnv.Typed().Get(name)
+
+		if reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+
+		changes = append(changes, &PropertyChange {
+			Name: name, OldValue: oldValue, NewValue: newValue,
+		})
+	}
+
+	return changes
+}
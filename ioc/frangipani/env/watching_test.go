@@ -0,0 +1,131 @@
+package env
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/mikelue/go-misc/utils"
+	"github.com/spf13/pflag"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Watching of files", func() {
+	tmpWorkingDir := utils.RollbackContainerBuilder.
+		NewTmpDir("fake-wd-watch-*")
+	var wdParams utils.Params
+	var chdir utils.RollbackContainer
+	var oldOsArgs []string
+	var sampleFile string
+
+	var testedEnv WatchedEnvironment
+	var eventsLock sync.Mutex
+	var receivedEvents []*ChangeEvent
+
+	/**
+	 * Writes the file by renaming a temporary one,
+	 * so the watcher would not see the truncated(intermediate) content
+	 */
+	writeSample := func(content string) {
+		tmpFile := sampleFile + ".tmp"
+		if err := ioutil.WriteFile(tmpFile, []byte(content), 0644); err != nil {
+			GinkgoT().Errorf("Unable to write file[%s]: %v", tmpFile, err)
+			return
+		}
+		if err := os.Rename(tmpFile, sampleFile); err != nil {
+			GinkgoT().Errorf("Unable to rename file[%s]: %v", tmpFile, err)
+		}
+	}
+	// :~)
+	getEvents := func() []*ChangeEvent {
+		eventsLock.Lock()
+		defer eventsLock.Unlock()
+		return append([]*ChangeEvent(nil), receivedEvents...)
+	}
+
+	BeforeEach(func() {
+		wdParams, _ = tmpWorkingDir.Setup()
+		newWd := wdParams[utils.PKEY_TEMP_DIR].(string)
+
+		sampleFile = fmt.Sprintf("%s/fig-config.yaml", newWd)
+		writeSample("db.host: 10.9.1.1\ndb.port: 5432\n")
+
+		chdir = utils.RollbackContainerBuilder.NewChdir(newWd)
+		chdir.Setup()
+
+		oldOsArgs = os.Args
+		os.Args = []string{}
+		receivedEvents = nil
+
+		testedEnv = NewConfigBuilder().
+			Prefix("fig").
+			Priority(CL_ARGS, CL_PWD).
+			Pflags(pflag.NewFlagSet("test-watching", pflag.ExitOnError)).
+			Watch(func(event *ChangeEvent) {
+				eventsLock.Lock()
+				defer eventsLock.Unlock()
+				receivedEvents = append(receivedEvents, event)
+			}).
+			Build().
+			ParseFlags().
+			Load().(WatchedEnvironment)
+	})
+	AfterEach(func() {
+		testedEnv.Close()
+		os.Args = oldOsArgs
+		chdir.TearDown()
+		tmpWorkingDir.TearDown(wdParams)
+	})
+
+	It("Reloads the changed file", func() {
+		Expect(testedEnv.GetProperty("db.host")).To(Equal("10.9.1.1"))
+
+		writeSample("db.host: 10.9.1.2\ndb.port: 5432\ndb.user: fig-user\n")
+
+		Eventually(func() string {
+			return testedEnv.GetProperty("db.host")
+		}, 3 * time.Second).Should(Equal("10.9.1.2"))
+		Eventually(func() string {
+			return testedEnv.GetProperty("db.user")
+		}, 3 * time.Second).Should(Equal("fig-user"))
+
+		/**
+		 * The file could be reloaded more than once, the changes are checked on the collected events
+		 */
+		Eventually(func() []PropertyChange {
+			var changes []PropertyChange
+			for _, event := range getEvents() {
+				Expect(event.Err).To(Succeed())
+				for _, change := range event.Changes {
+					changes = append(changes, *change)
+				}
+			}
+
+			return changes
+		}).Should(ContainElements(
+			PropertyChange{ "db.host", "10.9.1.1", "10.9.1.2" },
+			PropertyChange{ "db.user", nil, "fig-user" },
+		))
+		// :~)
+	})
+
+	It("Keeps previous snapshot for malformed file", func() {
+		writeSample("db.host: [10.9.1.3\n")
+
+		err := testedEnv.Reload()
+
+		Expect(err).To(MatchError(MatchRegexp(`Unable to parse file`)))
+		Expect(testedEnv.GetProperty("db.host")).To(Equal("10.9.1.1"))
+		Eventually(getEvents).ShouldNot(BeEmpty())
+		Expect(getEvents()[0].Err).To(HaveOccurred())
+	})
+
+	It("No event if nothing is changed", func() {
+		Expect(testedEnv.Reload()).To(Succeed())
+		Expect(getEvents()).To(BeEmpty())
+	})
+})
@@ -49,14 +49,18 @@ func (*workerBuilderI) newCmdDir(prefix string) loadingWorker {
 	newWorker.targetDir = dirOfCmd
 	return newWorker
 }
-func (*workerBuilderI) newFiles(fileNames ...string) loadingWorker {
+// The files are requested explicitly, the errors of loading are kept(see "loadingErrors()")
+func (*workerBuilderI) newFiles(prefix string, fileNames ...string) loadingWorker {
 	return &filesWorker {
 		files: fileNames,
+		required: true,
+		importProp: prefix + PROP_CONFIG_IMPORT,
 	}
 }
 
 var default_suffix_names = []string {
 	"-config.properties", "-config.yaml", "-config.json",
+	"-config.toml", "-config.hcl", "-config.env", "-config.ini",
 }
 
 func newDefaultFilesWorker(prefix string) *filesWorker {
@@ -68,37 +72,113 @@ func newDefaultFilesWorker(prefix string) *filesWorker {
 
 	return &filesWorker {
 		files: fileNames,
+		importProp: prefix + PROP_CONFIG_IMPORT,
 	}
 }
 
 type filesWorker struct {
 	files []string
 	targetDir string
+	// Whether or not the files are requested explicitly
+	required bool
+	// The property of imported files, e.g., "fgapp.config.import"
+	importProp string
+	// Cached vipers(without imported files)
+	rawFiles []*viper.Viper
 	// Cached vipers
 	loadedFiles []*viper.Viper
+	// Errors of required(or imported) files
+	errs []error
 }
 func (self *filesWorker) load() vipers {
 	if self.loadedFiles != nil {
 		return self.loadedFiles
 	}
 
-	configFiles := newConfigFiles(false, self.files...)
-	self.loadedFiles = configFiles.loadByDir(self.targetDir)
+	self.loadedFiles = self.withImports(self.loadRawFiles())
 
 	configLogger.Debugf("Found [%d] files.", len(self.loadedFiles))
 	return self.loadedFiles
 }
 func (self *filesWorker) loadWithProfiles(profiles ...string) vipers {
-	loadedFiles := self.load()
-
-	if self.targetDir == "" || len(profiles) == 0 {
-		return loadedFiles
+	if len(profiles) == 0 {
+		return self.load()
 	}
 
-	filesWithProfiles := newConfigFiles(false, self.files...).
-		loadByDirWithProfiles(self.targetDir, profiles...).
-		appendMore(loadedFiles...)
+	filesWithProfiles := make(vipers, 0)
+	if self.targetDir != "" {
+		filesWithProfiles = newConfigFiles(false, self.files...).
+			loadByDirWithProfiles(self.targetDir, profiles...)
+	}
+	filesWithProfiles = self.withImports(
+		filesWithProfiles.appendMore(self.loadRawFiles()...),
+		profiles...,
+	)
 
 	configLogger.Debugf("Found [%d] files(with profile).", len(filesWithProfiles))
 	return filesWithProfiles
 }
+func (self *filesWorker) loadRawFiles() vipers {
+	if self.rawFiles != nil {
+		return self.rawFiles
+	}
+
+	if self.required {
+		self.rawFiles = self.loadRequiredFiles()
+	} else {
+		self.rawFiles = newConfigFiles(false, self.files...).
+			loadByDir(self.targetDir)
+	}
+
+	return self.rawFiles
+}
+func (self *filesWorker) loadRequiredFiles() vipers {
+	loadedFiles := make(vipers, 0, len(self.files))
+
+	for _, fileName := range self.files {
+		fileName = filenameDir(fileName, self.targetDir)
+
+		viperObj, err := readInByFileE(fileName)
+		if err != nil {
+			logFileError(fileName, err, true)
+			self.addError(err)
+			continue
+		}
+
+		configLogger.Infof("Config file loaded: [%s]", fileName)
+		loadedFiles = append(loadedFiles, viperObj)
+	}
+
+	return loadedFiles
+}
+func (self *filesWorker) loadingErrors() []error {
+	return self.errs
+}
+// The same error(e.g., the one of imported file) may be met by loading with or without profiles
+func (self *filesWorker) addError(err error) {
+	for _, existingErr := range self.errs {
+		if existingErr.Error() == err.Error() {
+			return
+		}
+	}
+
+	self.errs = append(self.errs, err)
+}
+// Gets the paths of files(including the ones of profiles) which could be loaded by this worker
+func (self *filesWorker) candidateFiles(profiles ...string) []string {
+	candidates := make([]string, 0, len(self.files))
+
+	for _, fileName := range self.files {
+		candidates = append(candidates, filenameDir(fileName, self.targetDir))
+
+		if self.targetDir == "" {
+			continue
+		}
+
+		for _, profiledFile := range profiledFiles(fileName, profiles...) {
+			candidates = append(candidates, filenameDir(profiledFile, self.targetDir))
+		}
+	}
+
+	return candidates
+}
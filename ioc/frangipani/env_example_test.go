@@ -2,6 +2,8 @@ package frangipani
 
 import (
 	"fmt"
+	"time"
+
 	"github.com/spf13/viper"
 )
 
@@ -29,6 +31,44 @@ func ExampleEnvironment_acceptsProfiles() {
 	// Accept profiles[pf77 pf98]: false
 }
 
+func ExampleEnvironment_acceptsProfilesByExpression() {
+	env := EnvBuilder.NewByMap(map[string]interface{} {
+		PROP_ACITVE_PROFILES: "cloud,eu",
+	})
+
+	profiles, _ := ParseProfiles("cloud & (eu | us)", "!prod")
+	fmt.Printf("Accept profiles[cloud & (eu | us), !prod]: %v\n", env.AcceptsProfiles(profiles))
+
+	_, err := ParseProfiles("cloud & eu | us")
+	fmt.Printf("Error: %v\n", err)
+	// Output:
+	// Accept profiles[cloud & (eu | us), !prod]: true
+	// Error: Malformed profile expression "cloud & eu | us": mixing "&" and "|" without parentheses
+}
+
+func ExampleBindProperties() {
+	type DbConfig struct {
+		Host string `fg:"host" validate:"required"`
+		Port int `fg:"port:5432"`
+		Timeout time.Duration `fg:"timeout:10s"`
+	}
+
+	env := EnvBuilder.NewByMap(map[string]interface{} {
+		"db.primary.host": "10.71.8.91",
+		"db.primary.timeout": "30s",
+	})
+
+	var config DbConfig
+	if err := BindProperties(env, "db.primary", &config); err != nil {
+		fmt.Printf("Error: %v", err)
+		return
+	}
+
+	fmt.Printf("host: %s. port: %d. timeout: %v.", config.Host, config.Port, config.Timeout)
+	// Output:
+	// host: 10.71.8.91. port: 5432. timeout: 30s.
+}
+
 func ExampleIEnvBuilder_newByMap() {
 	env := EnvBuilder.NewByMap(map[string]interface{} {
 		"db.name": "irma",
@@ -69,3 +109,22 @@ func newViper(props testProps) *viper.Viper {
 
 	return newViper
 }
+
+func ExampleIEnvBuilder_newOverlay() {
+	loadedEnv := EnvBuilder.NewByMap(map[string]interface{} {
+		"db.host": "10.7.81.33",
+		"db.url": "pg://${db.host}:5432",
+	})
+
+	testEnv := EnvBuilder.NewOverlay(
+		loadedEnv,
+		map[string]interface{} { "db.host": "127.0.0.1" },
+		"it",
+	)
+
+	fmt.Printf("Overlay: %s %v\n", testEnv.GetProperty("db.url"), testEnv.GetActiveProfiles())
+	fmt.Printf("Original: %s %v\n", loadedEnv.GetProperty("db.url"), loadedEnv.GetActiveProfiles())
+	// Output:
+	// Overlay: pg://127.0.0.1:5432 [it default]
+	// Original: pg://10.7.81.33:5432 [default]
+}
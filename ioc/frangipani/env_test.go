@@ -42,4 +42,148 @@ var _ = Describe("Environment", func() {
 		Entry("2 profiles(trimming space)", "  a3 , c3  ,,", []interface{}{ "a3", "c3", DEFAULT_PROFILE }),
 		Entry("duplicated profiles", "a1,b2,a1", []interface{}{ "b2", "a1", DEFAULT_PROFILE }),
 	)
+
+	DescribeTable("GetActiveProfiles(groups and includes)",
+		func(props map[string]interface{}, expected []string) {
+			testedEnv := EnvBuilder.NewByMap(props)
+
+			Expect(testedEnv.GetActiveProfiles()).
+				To(Equal(expected))
+		},
+		Entry("group",
+			map[string]interface{} {
+				PROP_ACITVE_PROFILES: "prod,eu",
+				PROP_PROFILES_GROUP + ".prod": "db-pg, metrics",
+			},
+			[]string{ "prod", "db-pg", "metrics", "eu", DEFAULT_PROFILE },
+		),
+		Entry("nested group(with cycle)",
+			map[string]interface{} {
+				PROP_ACITVE_PROFILES: "prod",
+				PROP_PROFILES_GROUP + ".prod": []string{ "db-pg", "metrics" },
+				PROP_PROFILES_GROUP + ".db-pg": "pg-14,prod",
+			},
+			[]string{ "prod", "db-pg", "pg-14", "metrics", DEFAULT_PROFILE },
+		),
+		Entry("include",
+			map[string]interface{} {
+				PROP_ACITVE_PROFILES: "dev",
+				PROP_PROFILES_INCLUDE: []interface{}{ "tls", "dev" },
+				PROP_PROFILES_GROUP + ".tls": "certs",
+			},
+			[]string{ "dev", "tls", "certs", DEFAULT_PROFILE },
+		),
+		Entry("include(without active profiles)",
+			map[string]interface{} {
+				PROP_PROFILES_INCLUDE: "tls",
+			},
+			[]string{ "tls", DEFAULT_PROFILE },
+		),
+		Entry("group of default profile",
+			map[string]interface{} {
+				PROP_ACITVE_PROFILES: "default",
+				PROP_PROFILES_GROUP + ".default": "local",
+			},
+			[]string{ DEFAULT_PROFILE, "local" },
+		),
+		Entry("group of default profile(appended automatically)",
+			map[string]interface{} {
+				PROP_ACITVE_PROFILES: "dev",
+				PROP_PROFILES_GROUP + ".default": "local",
+			},
+			[]string{ "dev", DEFAULT_PROFILE, "local" },
+		),
+	)
+
+	Context("NewOverlay", func() {
+		baseEnv := EnvBuilder.NewByMap(map[string]interface{} {
+			PROP_ACITVE_PROFILES: "dev",
+			PROP_PROFILES_GROUP + ".it": "mock-mail",
+			"db.host": "10.7.81.33",
+			"db.url": "pg://${db.host}:${db.port:5432}",
+			"server.http-port": 8080,
+		})
+
+		testedEnv := EnvBuilder.NewOverlay(
+			baseEnv,
+			map[string]interface{} {
+				"db.host": "127.0.0.1",
+				"server.httpPort": 18080,
+			},
+			"it",
+		)
+
+		DescribeTable("Overridden properties",
+			func(name string, expectedOverlay string, expectedBase string) {
+				Expect(testedEnv.GetProperty(name)).To(Equal(expectedOverlay))
+				Expect(baseEnv.GetProperty(name)).To(Equal(expectedBase))
+			},
+			Entry("Overridden", "db.host", "127.0.0.1", "10.7.81.33"),
+			Entry("Placeholder of base", "db.url", "pg://127.0.0.1:5432", "pg://10.7.81.33:5432"),
+			Entry("Relaxed name", "server.http-port", "18080", "8080"),
+		)
+
+		It("Active profiles", func() {
+			Expect(testedEnv.GetActiveProfiles()).To(Equal([]string{ "dev", "it", "mock-mail", DEFAULT_PROFILE }))
+			Expect(baseEnv.GetActiveProfiles()).To(Equal([]string{ "dev", DEFAULT_PROFILE }))
+		})
+
+		It("Overlay of overlay", func() {
+			nestedEnv := EnvBuilder.NewOverlay(testedEnv, map[string]interface{} { "db.port": 6432 })
+
+			Expect(nestedEnv.GetProperty("db.url")).To(Equal("pg://127.0.0.1:6432"))
+			Expect(nestedEnv.AcceptsProfiles(OfProfiles("it"))).To(BeTrue())
+		})
+
+		It("Random values are shared with base", func() {
+			nestedEnv := EnvBuilder.NewOverlay(testedEnv, map[string]interface{} { "server.port": "${random.port}" })
+
+			Expect(testedEnv.GetProperty("random.uuid")).To(Equal(baseEnv.GetProperty("random.uuid")))
+			Expect(nestedEnv.GetProperty("random.uuid")).To(Equal(baseEnv.GetProperty("random.uuid")))
+			Expect(nestedEnv.Typed().GetInt("server.port")).To(Equal(baseEnv.Typed().GetInt("random.port")))
+		})
+
+		Context("Base environment cannot list its properties", func() {
+			externalEnv := &sampleExternalEnv{ baseEnv }
+			testedEnv := EnvBuilder.NewOverlay(
+				externalEnv,
+				map[string]interface{} {
+					"db.port": 6432,
+					"server.httpPort": 18080,
+				},
+				"it",
+			)
+
+			DescribeTable("Properties",
+				func(name string, expected string) {
+					Expect(testedEnv.GetProperty(name)).To(Equal(expected))
+				},
+				Entry("Overridden", "db.port", "6432"),
+				Entry("Relaxed name", "server.http-port", "18080"),
+				Entry("Looked up by base", "db.host", "10.7.81.33"),
+				Entry("Placeholder of base(resolved by base)", "db.url", "pg://10.7.81.33:5432"),
+			)
+
+			It("Random values are shared with base", func() {
+				Expect(testedEnv.GetProperty("random.value")).To(Equal(externalEnv.GetProperty("random.value")))
+			})
+
+			It("Active profiles", func() {
+				Expect(testedEnv.GetActiveProfiles()).To(Equal([]string{ "dev", "it", "mock-mail", DEFAULT_PROFILE }))
+			})
+
+			It("Listed properties", func() {
+				props, ok := AllProperties(testedEnv)
+
+				Expect(ok).To(BeTrue())
+				Expect(props).To(HaveKey("db.port"))
+				Expect(props).NotTo(HaveKey("db.host"))
+			})
+		})
+	})
 })
+
+// The environment which cannot list its properties(not implementing "PropertiesLister")
+type sampleExternalEnv struct {
+	Environment
+}
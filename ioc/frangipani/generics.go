@@ -0,0 +1,34 @@
+package frangipani
+
+// Gets the property as type "T", which is converted by "GetAs()".
+//
+//   port, err := fg.Get[int](env, "server.port")
+//   dbConfig, err := fg.Get[DbConfig](env, "db")
+//   servers, err := fg.Get[[]ServerConfig](env, "servers")
+//
+// The structs are decoded by "fg" tags(or "mapstructure" tags), see "Binding of properties".
+func Get[T any](resolver PropertyResolver, name string) (T, error) {
+	var result T
+	err := GetAs(resolver, name, &result)
+	return result, err
+}
+
+// Gets the property as type "T", the error of "Get[T]()" is raised as panic.
+func MustGet[T any](resolver PropertyResolver, name string) T {
+	result, err := Get[T](resolver, name)
+	if err != nil {
+		panic(err)
+	}
+
+	return result
+}
+
+// Gets the property as type "T", the default value is returned if the property is not existing(or cannot be converted).
+func GetOr[T any](resolver PropertyResolver, name string, defaultValue T) T {
+	result, err := Get[T](resolver, name)
+	if err != nil {
+		return defaultValue
+	}
+
+	return result
+}
@@ -0,0 +1,78 @@
+package frangipani
+
+import (
+	"net/url"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Generic accessors", func() {
+	type serverConfig struct {
+		Host string `mapstructure:"host"`
+		HttpPort int `mapstructure:"http_port"`
+	}
+	type baseConfig struct {
+		Timeout time.Duration `mapstructure:"timeout"`
+	}
+	type dbConfig struct {
+		Base baseConfig `mapstructure:",squash"`
+		Url *url.URL `mapstructure:"url"`
+		Ignored string `mapstructure:"-"`
+	}
+
+	testedEnv := EnvBuilder.NewByMap(map[string]interface{} {
+		"db.url": "pg://${db.host}:5432/sugar",
+		"db.host": "10.7.81.33",
+		"db.timeout": "3s",
+		"db.ignored": "value",
+		"servers": []interface{} {
+			map[string]interface{} { "host": "10.0.0.1", "http-port": 80 },
+			map[string]interface{} { "host": "10.0.0.2", "http-port": 81 },
+		},
+		"size": "x20",
+	})
+
+	It("Get[int]", func() {
+		Expect(Get[int](testedEnv, "servers[1].http-port")).To(Equal(81))
+	})
+
+	It("Get[struct]", func() {
+		testedConfig, err := Get[dbConfig](testedEnv, "db")
+
+		Expect(err).To(Succeed())
+		Expect(testedConfig.Url.Host).To(Equal("10.7.81.33:5432"))
+		Expect(testedConfig.Base.Timeout).To(Equal(3 * time.Second))
+		Expect(testedConfig.Ignored).To(BeEmpty())
+	})
+
+	It("Get[[]struct]", func() {
+		Expect(Get[[]serverConfig](testedEnv, "servers")).To(Equal([]serverConfig {
+			{ "10.0.0.1", 80 }, { "10.0.0.2", 81 },
+		}))
+	})
+
+	DescribeTable("Get(error)",
+		func(name string, expectedErr string) {
+			_, err := Get[int](testedEnv, name)
+			Expect(err).To(MatchError(MatchRegexp(expectedErr)))
+		},
+		Entry("Not existing", "no-such", `Property\[no-such\] is not existing`),
+		Entry("Conversion error", "size", `Property\[size\] cannot be converted`),
+	)
+
+	It("MustGet", func() {
+		Expect(MustGet[string](testedEnv, "db.host")).To(Equal("10.7.81.33"))
+		Expect(func() { MustGet[int](testedEnv, "size") }).To(PanicWith(MatchError(MatchRegexp(`Property\[size\]`))))
+	})
+
+	DescribeTable("GetOr",
+		func(name string, expected time.Duration) {
+			Expect(GetOr(testedEnv, name, time.Minute)).To(Equal(expected))
+		},
+		Entry("Existing", "db.timeout", 3 * time.Second),
+		Entry("Not existing", "db.no-such", time.Minute),
+		Entry("Conversion error", "size", time.Minute),
+	)
+})
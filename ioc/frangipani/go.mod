@@ -1,23 +1,42 @@
 module github.com/mikelue/go-misc/ioc/frangipani
 
-go 1.14
+go 1.18
 
 require (
 	flamingo.me/dingo v0.2.9
+	github.com/fsnotify/fsnotify v1.5.4
 	github.com/go-eden/slf4go v1.0.7
+	github.com/go-playground/validator/v10 v10.2.0
 	github.com/inhies/go-bytesize v0.0.0-20200716184324-4fe85e9b81b2
-	github.com/mikelue/go-misc v0.0.0-20220615055056-ca65fab93f7c // indirect
 	github.com/mikelue/go-misc/utils v0.0.0-20220615055056-ca65fab93f7c
-	github.com/onsi/ginkgo v1.16.5 // indirect
 	github.com/onsi/ginkgo/v2 v2.1.4
 	github.com/onsi/gomega v1.19.0
-	github.com/pelletier/go-toml/v2 v2.0.2 // indirect
 	github.com/spf13/cast v1.5.0
 	github.com/spf13/pflag v1.0.5
 	github.com/spf13/viper v1.12.0
-	github.com/subosito/gotenv v1.4.0 // indirect
 	github.com/thoas/go-funk v0.7.0
+	github.com/xeipuuv/gojsonschema v1.2.0
+)
+
+require (
+	github.com/go-playground/locales v0.13.0 // indirect
+	github.com/go-playground/universal-translator v0.17.0 // indirect
+	github.com/hashicorp/hcl v1.0.0 // indirect
+	github.com/leodido/go-urn v1.2.0 // indirect
+	github.com/magiconair/properties v1.8.6 // indirect
+	github.com/mikelue/go-misc v0.0.0-20220615055056-ca65fab93f7c // indirect
+	github.com/mitchellh/mapstructure v1.5.0 // indirect
+	github.com/onsi/ginkgo v1.16.5 // indirect
+	github.com/pelletier/go-toml/v2 v2.0.2 // indirect
+	github.com/spf13/afero v1.8.2 // indirect
+	github.com/spf13/jwalterweatherman v1.1.0 // indirect
+	github.com/subosito/gotenv v1.4.0 // indirect
+	github.com/xeipuuv/gojsonpointer v0.0.0-20180127040702-4e3ac2762d5f // indirect
+	github.com/xeipuuv/gojsonreference v0.0.0-20180127040603-bd5ef7bd5415 // indirect
 	golang.org/x/net v0.0.0-20220614195744-fb05da6f9022 // indirect
 	golang.org/x/sys v0.0.0-20220614162138-6c1b26c55098 // indirect
+	golang.org/x/text v0.3.7 // indirect
 	gopkg.in/ini.v1 v1.66.6 // indirect
+	gopkg.in/yaml.v2 v2.4.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,121 @@
+package frangipani
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// The metadata of property, which is registered by libraries(or applications) to describe the accepted properties.
+//
+//   fg.RegisterMetadata(
+//     fg.PropertyMetadata{ Name: "db.host", Type: "string", Default: "localhost", Description: "Host of database" },
+//     fg.PropertyMetadata{ Name: "db.hostname", Type: "string", Deprecated: true, Replacement: "db.host" },
+//   )
+//
+// See "WriteReferenceAsMarkdown()" and "WriteReferenceAsJson()" for generated documents.
+type PropertyMetadata struct {
+	// The name of property, e.g., "db.pool.max-size"
+	Name string `json:"name"`
+	// The type of value, e.g., "int", "duration", or "[]string"
+	Type string `json:"type,omitempty"`
+	// The default value(as text)
+	Default string `json:"default,omitempty"`
+	Description string `json:"description,omitempty"`
+	// Whether or not the property is deprecated
+	Deprecated bool `json:"deprecated,omitempty"`
+	// The name of property replacing this deprecated one
+	Replacement string `json:"replacement,omitempty"`
+}
+
+var (
+	registeredMetadata = make(map[string]PropertyMetadata)
+	metadataLock sync.RWMutex
+)
+
+// Registers metadata of properties, the one having the same(canonical) name is replaced.
+func RegisterMetadata(metadata ...PropertyMetadata) {
+	metadataLock.Lock()
+	defer metadataLock.Unlock()
+
+	for _, m := range metadata {
+		registeredMetadata[relaxedName(m.Name)] = m
+	}
+	rebuildAliasIndex()
+}
+
+// Gets the metadata of property, which is matched by relaxed names(see "CanonicalName").
+func GetMetadata(name string) (PropertyMetadata, bool) {
+	metadataLock.RLock()
+	defer metadataLock.RUnlock()
+
+	m, ok := registeredMetadata[relaxedName(name)]
+	return m, ok
+}
+
+// Gets all of the registered metadata(sorted by name).
+func AllMetadata() []PropertyMetadata {
+	metadataLock.RLock()
+	defer metadataLock.RUnlock()
+
+	allMetadata := make([]PropertyMetadata, 0, len(registeredMetadata))
+	for _, m := range registeredMetadata {
+		allMetadata = append(allMetadata, m)
+	}
+
+	sort.Slice(allMetadata, func(i, j int) bool {
+		return allMetadata[i].Name < allMetadata[j].Name
+	})
+
+	return allMetadata
+}
+
+// Writes the reference of registered properties as a table of Markdown.
+func WriteReferenceAsMarkdown(writer io.Writer) error {
+	lines := []string {
+		"| Name | Type | Default | Description |",
+		"|------|------|---------|-------------|",
+	}
+
+	for _, m := range AllMetadata() {
+		name := fmt.Sprintf("`%s`", m.Name)
+		description := m.Description
+
+		if m.Deprecated {
+			name = fmt.Sprintf("~~%s~~", name)
+
+			deprecation := "**Deprecated**"
+			if m.Replacement != "" {
+				deprecation = fmt.Sprintf("**Deprecated**(use `%s`)", m.Replacement)
+			}
+			description = strings.TrimSpace(deprecation + " " + description)
+		}
+
+		defaultValue := ""
+		if m.Default != "" {
+			defaultValue = fmt.Sprintf("`%s`", m.Default)
+		}
+
+		lines = append(lines, fmt.Sprintf("| %s | %s | %s | %s |",
+			name, escapeMarkdownCell(m.Type), escapeMarkdownCell(defaultValue), escapeMarkdownCell(description),
+		))
+	}
+
+	_, err := io.WriteString(writer, strings.Join(lines, "\n") + "\n")
+	return err
+}
+
+// Writes the reference of registered properties as JSON array.
+func WriteReferenceAsJson(writer io.Writer) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(AllMetadata())
+}
+
+func escapeMarkdownCell(text string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(text)
+}
@@ -0,0 +1,77 @@
+package frangipani
+
+import (
+	"encoding/json"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Metadata of properties", func() {
+	var oldMetadata map[string]PropertyMetadata
+
+	BeforeEach(func() {
+		oldMetadata = registeredMetadata
+		registeredMetadata = make(map[string]PropertyMetadata)
+
+		RegisterMetadata(
+			PropertyMetadata{ Name: "db.pool.max-size", Type: "int", Default: "10", Description: "Maximum size | of pool" },
+			PropertyMetadata{ Name: "db.host", Type: "string", Default: "localhost", Description: "Host of database" },
+			PropertyMetadata{ Name: "db.hostname", Type: "string", Deprecated: true, Replacement: "db.host" },
+		)
+	})
+	AfterEach(func() {
+		registeredMetadata = oldMetadata
+		rebuildAliasIndex()
+	})
+
+	DescribeTable("GetMetadata",
+		func(name string, expected string) {
+			metadata, ok := GetMetadata(name)
+
+			if expected == "" {
+				Expect(ok).To(BeFalse())
+				return
+			}
+
+			Expect(ok).To(BeTrue())
+			Expect(metadata.Name).To(Equal(expected))
+		},
+		Entry("Exact name", "db.host", "db.host"),
+		Entry("Relaxed name", "db.pool.maxSize", "db.pool.max-size"),
+		Entry("Not registered", "db.port", ""),
+	)
+
+	It("AllMetadata(sorted)", func() {
+		names := make([]string, 0)
+		for _, metadata := range AllMetadata() {
+			names = append(names, metadata.Name)
+		}
+
+		Expect(names).To(Equal([]string{ "db.host", "db.hostname", "db.pool.max-size" }))
+	})
+
+	It("WriteReferenceAsMarkdown", func() {
+		var markdown strings.Builder
+		Expect(WriteReferenceAsMarkdown(&markdown)).To(Succeed())
+
+		Expect(strings.Split(strings.TrimSpace(markdown.String()), "\n")).To(Equal([]string {
+			"| Name | Type | Default | Description |",
+			"|------|------|---------|-------------|",
+			"| `db.host` | string | `localhost` | Host of database |",
+			"| ~~`db.hostname`~~ | string |  | **Deprecated**(use `db.host`) |",
+			"| `db.pool.max-size` | int | `10` | Maximum size \\| of pool |",
+		}))
+	})
+
+	It("WriteReferenceAsJson", func() {
+		var jsonText strings.Builder
+		Expect(WriteReferenceAsJson(&jsonText)).To(Succeed())
+
+		var testedMetadata []PropertyMetadata
+		Expect(json.Unmarshal([]byte(jsonText.String()), &testedMetadata)).To(Succeed())
+		Expect(testedMetadata).To(Equal(AllMetadata()))
+		Expect(jsonText.String()).To(ContainSubstring(`"replacement": "db.host"`))
+	})
+})
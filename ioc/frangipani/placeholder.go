@@ -0,0 +1,211 @@
+package frangipani
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cast"
+)
+
+const (
+	// The leading text of placeholder: "${"
+	PLACEHOLDER_PREFIX = "${"
+	// The tailing text of placeholder: "}"
+	PLACEHOLDER_SUFFIX = "}"
+	// The separator between name of property and its default value: "${name:default}"
+	PLACEHOLDER_VALUE_SEPARATOR = ":"
+	// Used to put literal "${" in value of property: "\${"
+	PLACEHOLDER_ESCAPE = `\`
+)
+
+func newPlaceholderResolver(resolver mapBasedPropertyResolver) *placeholderResolver {
+	return &placeholderResolver {
+		resolver: resolver,
+		visiting: make(map[string]bool),
+	}
+}
+
+// Resolves "${name}" and "${name:default}" in value of properties.
+//
+// The placeholders could be nested, e.g., "${db.${env}.host:localhost}".
+type placeholderResolver struct {
+	// Looks up properties(including relaxed names and "random.*")
+	resolver mapBasedPropertyResolver
+	// Names of properties being resolved, used to detect circular reference
+	visiting map[string]bool
+}
+// Resolves the placeholders of value in property.
+//
+// Only string(including elements in slices and maps) would be resolved.
+func (self *placeholderResolver) resolveProperty(name string, value interface{}) (interface{}, error) {
+	if self.visiting[name] {
+		return nil, fmt.Errorf("Property[%s] has circular reference of placeholder", name)
+	}
+
+	self.visiting[name] = true
+	defer delete(self.visiting, name)
+
+	return self.resolveValue(value)
+}
+func (self *placeholderResolver) resolveValue(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		return self.resolveText(v)
+	case []string:
+		resolved := make([]string, len(v))
+		for i, elem := range v {
+			text, err := self.resolveText(elem)
+			if err != nil {
+				return nil, err
+			}
+			resolved[i] = text
+		}
+		return resolved, nil
+	case []interface{}:
+		resolved := make([]interface{}, len(v))
+		for i, elem := range v {
+			resolvedElem, err := self.resolveValue(elem)
+			if err != nil {
+				return nil, err
+			}
+			resolved[i] = resolvedElem
+		}
+		return resolved, nil
+	case map[string]interface{}:
+		resolved := make(map[string]interface{}, len(v))
+		for k, elem := range v {
+			resolvedElem, err := self.resolveValue(elem)
+			if err != nil {
+				return nil, err
+			}
+			resolved[k] = resolvedElem
+		}
+		return resolved, nil
+	}
+
+	return value, nil
+}
+func (self *placeholderResolver) resolveText(text string) (string, error) {
+	if !strings.Contains(text, PLACEHOLDER_PREFIX) {
+		return text, nil
+	}
+
+	var result strings.Builder
+
+	for i := 0; i < len(text); {
+		/**
+		 * Escaped placeholder("\${") is kept as literal "${"
+		 */
+		if strings.HasPrefix(text[i:], PLACEHOLDER_ESCAPE + PLACEHOLDER_PREFIX) {
+			result.WriteString(PLACEHOLDER_PREFIX)
+			i += len(PLACEHOLDER_ESCAPE) + len(PLACEHOLDER_PREFIX)
+			continue
+		}
+		// :~)
+
+		if !strings.HasPrefix(text[i:], PLACEHOLDER_PREFIX) {
+			result.WriteByte(text[i])
+			i++
+			continue
+		}
+
+		/**
+		 * Keeps the text if there is no matched suffix
+		 */
+		endIndex := findPlaceholderEnd(text, i)
+		if endIndex == -1 {
+			result.WriteString(text[i:])
+			break
+		}
+		// :~)
+
+		resolved, err := self.resolvePlaceholder(
+			text[i + len(PLACEHOLDER_PREFIX):endIndex],
+		)
+		if err != nil {
+			return "", err
+		}
+
+		result.WriteString(resolved)
+		i = endIndex + len(PLACEHOLDER_SUFFIX)
+	}
+
+	return result.String(), nil
+}
+// Resolves content of a placeholder(without "${" and "}")
+func (self *placeholderResolver) resolvePlaceholder(content string) (string, error) {
+	name, defaultValue, hasDefault := splitPlaceholder(content)
+
+	name, err := self.resolveText(name)
+	if err != nil {
+		return "", err
+	}
+
+	value, ok, err := self.resolver.lookup(name)
+	if err != nil {
+		return "", err
+	}
+	if ok {
+		resolvedValue, err := self.resolveProperty(name, value)
+		if err != nil {
+			return "", err
+		}
+
+		return cast.ToStringE(resolvedValue)
+	}
+
+	if hasDefault {
+		return self.resolveText(defaultValue)
+	}
+
+	return "", fmt.Errorf("Placeholder \"${%s}\" could not be resolved", name)
+}
+
+// Finds the index of suffix matching the prefix at "startIndex", nested placeholders are skipped.
+//
+// Returns -1 if there is no matched suffix.
+func findPlaceholderEnd(text string, startIndex int) int {
+	depth := 0
+
+	for i := startIndex; i < len(text); {
+		switch {
+		case strings.HasPrefix(text[i:], PLACEHOLDER_ESCAPE + PLACEHOLDER_PREFIX):
+			i += len(PLACEHOLDER_ESCAPE) + len(PLACEHOLDER_PREFIX)
+		case strings.HasPrefix(text[i:], PLACEHOLDER_PREFIX):
+			depth++
+			i += len(PLACEHOLDER_PREFIX)
+		case strings.HasPrefix(text[i:], PLACEHOLDER_SUFFIX):
+			depth--
+			if depth == 0 {
+				return i
+			}
+			i += len(PLACEHOLDER_SUFFIX)
+		default:
+			i++
+		}
+	}
+
+	return -1
+}
+
+// Splits "name:default" by the first separator which is not in nested placeholder.
+func splitPlaceholder(content string) (name string, defaultValue string, hasDefault bool) {
+	depth := 0
+
+	for i := 0; i < len(content); {
+		switch {
+		case strings.HasPrefix(content[i:], PLACEHOLDER_PREFIX):
+			depth++
+			i += len(PLACEHOLDER_PREFIX)
+		case strings.HasPrefix(content[i:], PLACEHOLDER_SUFFIX):
+			depth--
+			i += len(PLACEHOLDER_SUFFIX)
+		case depth == 0 && strings.HasPrefix(content[i:], PLACEHOLDER_VALUE_SEPARATOR):
+			return content[:i], content[i + len(PLACEHOLDER_VALUE_SEPARATOR):], true
+		default:
+			i++
+		}
+	}
+
+	return content, "", false
+}
@@ -0,0 +1,114 @@
+package frangipani
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Placeholder", func() {
+	sampleProps := map[string]interface{} {
+		"db.host": "10.7.81.33",
+		"db.port": 5432,
+		"db.url": "pg://${db.host}:${db.port}",
+		"db.env": "ut",
+		"db.ut.name": "sugar-cane",
+		"db.name": "${db.${db.env}.name}",
+		"db.replicas": []interface{}{ "${db.host}", "10.7.81.34" },
+		"db.tags": []string{ "${db.env}", "pg" },
+		"cycle.a": "${cycle.b}",
+		"cycle.b": "${cycle.c}",
+		"cycle.c": "${cycle.a}",
+		"self.ref": "x-${self.ref}",
+		"not.resolved": "${no.such.key}",
+	}
+
+	Context("resolveText", func() {
+		DescribeTable("Resolved text",
+			func(sampleText string, expected string) {
+				testedText, err := newPlaceholderResolver(newMapBasedPropertyResolver(sampleProps)).
+					resolveText(sampleText)
+
+				Expect(err).To(Succeed())
+				Expect(testedText).To(BeEquivalentTo(expected))
+			},
+			Entry("No placeholder", "plain text", "plain text"),
+			Entry("Single placeholder", "${db.host}", "10.7.81.33"),
+			Entry("Non-string value", "${db.port}", "5432"),
+			Entry("Multiple placeholders", "${db.host}:${db.port}", "10.7.81.33:5432"),
+			Entry("Nested resolution", "${db.url}/x", "pg://10.7.81.33:5432/x"),
+			Entry("Nested name", "${db.${db.env}.name}", "sugar-cane"),
+			Entry("Default value", "${db.user:postgres}", "postgres"),
+			Entry("Empty default value", "${db.user:}", ""),
+			Entry("Default value is ignored", "${db.host:127.0.0.1}", "10.7.81.33"),
+			Entry("Default value with separator", "${db.user:a:b}", "a:b"),
+			Entry("Default value as placeholder", "${db.user:${db.env}}", "ut"),
+			Entry("Escaped placeholder", `\${db.host}`, "${db.host}"),
+			Entry("Escaped placeholder(mixed)", `${db.env}-\${db.env}`, "ut-${db.env}"),
+			Entry("No matched suffix", "${db.host", "${db.host"),
+		)
+
+		DescribeTable("Error of resolving",
+			func(sampleText string, expectedErr string) {
+				_, err := newPlaceholderResolver(newMapBasedPropertyResolver(sampleProps)).
+					resolveText(sampleText)
+
+				Expect(err).To(MatchError(MatchRegexp(expectedErr)))
+			},
+			Entry("Not existing", "${db.user}", `"\$\{db.user\}" could not be resolved`),
+			Entry("Circular reference", "${cycle.a}", `circular reference`),
+			Entry("Self reference", "${self.ref}", `Property\[self.ref\] has circular`),
+		)
+	})
+
+	Context("resolveProperty", func() {
+		DescribeTable("Resolved values in slices",
+			func(name string, expected []interface{}) {
+				testedValue, err := newPlaceholderResolver(newMapBasedPropertyResolver(sampleProps)).
+					resolveProperty(name, sampleProps[name])
+
+				Expect(err).To(Succeed())
+				Expect(testedValue).To(ConsistOf(expected...))
+			},
+			Entry("[]interface{}", "db.replicas", []interface{}{ "10.7.81.33", "10.7.81.34" }),
+			Entry("[]string", "db.tags", []interface{}{ "ut", "pg" }),
+		)
+	})
+
+	Context("By PropertyResolver", func() {
+		testedResolver := PropertyResolverBuilder.NewByMap(sampleProps)
+
+		It("GetProperty", func() {
+			Expect(testedResolver.GetProperty("db.name")).
+				To(BeEquivalentTo("sugar-cane"))
+		})
+
+		It("Typed(keeps original value if placeholder is unresolvable)", func() {
+			Expect(testedResolver.Typed().GetString("not.resolved")).
+				To(BeEquivalentTo("${no.such.key}"))
+			Expect(testedResolver.Typed().GetString("cycle.a")).
+				To(BeEquivalentTo("${cycle.b}"))
+		})
+
+		DescribeTable("RequiredTyped(error)",
+			func(name string, expectedErr string) {
+				_, err := testedResolver.RequiredTyped().GetString(name)
+
+				Expect(err).To(MatchError(MatchRegexp(expectedErr)))
+			},
+			Entry("Not existing", "not.resolved", `Property\[not.resolved\] has unresolvable placeholder`),
+			Entry("Circular reference", "cycle.a", `circular reference`),
+		)
+
+		It("RequiredTyped(typed value)", func() {
+			testedResolver := PropertyResolverBuilder.NewByMap(map[string]interface{} {
+				"server.port": 8080,
+				"admin.port": "${server.port}",
+			})
+
+			port, err := testedResolver.RequiredTyped().GetInt("admin.port")
+
+			Expect(err).To(Succeed())
+			Expect(port).To(BeEquivalentTo(8080))
+		})
+	})
+})
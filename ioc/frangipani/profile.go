@@ -7,6 +7,13 @@ For example(by "viper")：
 
 	viperObj := viper.New()
 	viperObj.Set(PROP_ACITVE_PROFILES, "a1,a2")
+
+Expression of profiles
+
+You can use "ParseProfiles" to build "Profiles" by expressions like "cloud & (eu | us)".
+
+	profiles, err := ParseProfiles("!prod", "cloud & (eu | us)")
+	env.AcceptsProfiles(profiles)
 */
 package frangipani
 
@@ -0,0 +1,214 @@
+package frangipani
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Parses expressions of profiles as "Profiles".
+//
+// The syntax of an expression(as Spring Framework):
+//
+//   "prod" - The profile "prod" is active
+//   "!prod" - The profile "prod" is not active
+//   "cloud & eu" - Both of the profiles are active
+//   "eu | us" - Either of the profiles is active
+//   "cloud & (eu | us)" - Mixing of "&" and "|" must be grouped by parentheses
+//
+// For multiple expressions, all of them must be matched(as same as "OfProfiles").
+//
+// The error is viable if any of the expressions is malformed.
+func ParseProfiles(expressions ...string) (Profiles, error) {
+	parsedProfiles := make(andProfiles, 0, len(expressions))
+
+	for _, expression := range expressions {
+		if strings.TrimSpace(expression) == "" {
+			continue
+		}
+
+		profiles, err := parseProfileExpression(expression)
+		if err != nil {
+			return nil, err
+		}
+
+		parsedProfiles = append(parsedProfiles, profiles)
+	}
+
+	return parsedProfiles, nil
+}
+
+func parseProfileExpression(expression string) (Profiles, error) {
+	parser := &profileParser{
+		expression: expression,
+		tokens: tokenizeProfileExpression(expression),
+	}
+
+	profiles, err := parser.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+
+	if parser.hasNext() {
+		return nil, parser.newError("unexpected \"%s\"", parser.peek())
+	}
+
+	return profiles, nil
+}
+
+const (
+	profileTokenAnd = "&"
+	profileTokenOr = "|"
+	profileTokenNot = "!"
+	profileTokenOpen = "("
+	profileTokenClose = ")"
+)
+
+func tokenizeProfileExpression(expression string) []string {
+	tokens := make([]string, 0)
+	var name strings.Builder
+
+	flushName := func() {
+		if name.Len() > 0 {
+			tokens = append(tokens, name.String())
+			name.Reset()
+		}
+	}
+
+	for _, c := range expression {
+		switch {
+		case strings.ContainsRune("&|!()", c):
+			flushName()
+			tokens = append(tokens, string(c))
+		case unicode.IsSpace(c):
+			flushName()
+		default:
+			name.WriteRune(c)
+		}
+	}
+	flushName()
+
+	return tokens
+}
+
+// Recursive descent parser:
+//
+//   expression := unary { "&" unary } | unary { "|" unary }
+//   unary := "!" unary | "(" expression ")" | <profile>
+type profileParser struct {
+	expression string
+	tokens []string
+	position int
+}
+func (self *profileParser) parseExpression() (Profiles, error) {
+	first, err := self.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	operands := []Profiles{ first }
+	operator := ""
+
+	for self.hasNext() {
+		token := self.peek()
+		if token != profileTokenAnd && token != profileTokenOr {
+			break
+		}
+
+		if operator != "" && operator != token {
+			return nil, self.newError("mixing \"&\" and \"|\" without parentheses")
+		}
+		operator = token
+		self.position++
+
+		operand, err := self.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, operand)
+	}
+
+	switch operator {
+	case profileTokenAnd:
+		return andProfiles(operands), nil
+	case profileTokenOr:
+		return orProfiles(operands), nil
+	}
+
+	return first, nil
+}
+func (self *profileParser) parseUnary() (Profiles, error) {
+	if !self.hasNext() {
+		return nil, self.newError("unexpected end of expression")
+	}
+
+	token := self.peek()
+	self.position++
+
+	switch token {
+	case profileTokenNot:
+		operand, err := self.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notProfiles{ operand }, nil
+	case profileTokenOpen:
+		grouped, err := self.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+
+		if !self.hasNext() || self.peek() != profileTokenClose {
+			return nil, self.newError("missing \")\"")
+		}
+		self.position++
+
+		return grouped, nil
+	case profileTokenAnd, profileTokenOr, profileTokenClose:
+		return nil, self.newError("unexpected \"%s\"", token)
+	}
+
+	return ofProfilesImpl{ token }, nil
+}
+func (self *profileParser) hasNext() bool {
+	return self.position < len(self.tokens)
+}
+func (self *profileParser) peek() string {
+	return self.tokens[self.position]
+}
+func (self *profileParser) newError(format string, args ...interface{}) error {
+	return fmt.Errorf("Malformed profile expression \"%s\": %s",
+		self.expression, fmt.Sprintf(format, args...))
+}
+
+// All of the profiles must be matched
+type andProfiles []Profiles
+func (self andProfiles) Matches(matchFunc func(string) bool) bool {
+	for _, profiles := range self {
+		if !profiles.Matches(matchFunc) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Any of the profiles must be matched
+type orProfiles []Profiles
+func (self orProfiles) Matches(matchFunc func(string) bool) bool {
+	for _, profiles := range self {
+		if profiles.Matches(matchFunc) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// The profiles must not be matched
+type notProfiles struct {
+	profiles Profiles
+}
+func (self notProfiles) Matches(matchFunc func(string) bool) bool {
+	return !self.profiles.Matches(matchFunc)
+}
@@ -0,0 +1,59 @@
+package frangipani
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Expression of profiles", func() {
+	DescribeTable("ParseProfiles",
+		func(expressions []string, expected bool) {
+			testedProfiles, err := ParseProfiles(expressions...)
+
+			Expect(err).To(Succeed())
+			Expect(testedProfiles.Matches(matchM1AndM2)).
+				To(BeEquivalentTo(expected))
+		},
+		Entry("Single profile", []string{ "m1" }, true),
+		Entry("Negation", []string{ "!m1" }, false),
+		Entry("Negation(not active)", []string{ "!n1" }, true),
+		Entry("Double negation", []string{ "!!m1" }, true),
+		Entry("And", []string{ "m1 & m2" }, true),
+		Entry("And(not matched)", []string{ "m1 & n1" }, false),
+		Entry("Or", []string{ "n1 | m2" }, true),
+		Entry("Or(not matched)", []string{ "n1 | n2" }, false),
+		Entry("Grouping", []string{ "m1 & (n1 | m2)" }, true),
+		Entry("Grouping(not matched)", []string{ "m1 & !(n1 | m2)" }, false),
+		Entry("Nested grouping", []string{ "((m1))&(m2|(n1&n2))" }, true),
+		Entry("Multiple expressions", []string{ "m1", "!n1" }, true),
+		Entry("Multiple expressions(not matched)", []string{ "m1", "n1 | n2" }, false),
+		Entry("Empty", []string{ "", "  " }, true),
+	)
+
+	DescribeTable("Malformed expression",
+		func(expression string, expectedErr string) {
+			_, err := ParseProfiles(expression)
+
+			Expect(err).To(MatchError(MatchRegexp(expectedErr)))
+		},
+		Entry("Mixing operators", "m1 & m2 | n1", `mixing "&" and "\|"`),
+		Entry("Missing operand", "m1 &", `unexpected end`),
+		Entry("Missing closing parenthesis", "(m1 | m2", `missing "\)"`),
+		Entry("Redundant closing parenthesis", "m1)", `unexpected "\)"`),
+		Entry("Leading operator", "| m1", `unexpected "\|"`),
+		Entry("Missing operator", "m1 m2", `unexpected "m2"`),
+		Entry("Only negation", "!", `unexpected end`),
+	)
+
+	It("AcceptsProfiles of Environment", func() {
+		testedEnv := EnvBuilder.NewByMap(map[string]interface{} {
+			PROP_ACITVE_PROFILES: "cloud,eu",
+		})
+
+		profiles, _ := ParseProfiles("cloud & (eu | us) & !prod")
+		Expect(testedEnv.AcceptsProfiles(profiles)).To(BeTrue())
+
+		profiles, _ = ParseProfiles("cloud & us")
+		Expect(testedEnv.AcceptsProfiles(profiles)).To(BeFalse())
+	})
+})
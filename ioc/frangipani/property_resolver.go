@@ -2,6 +2,7 @@ package frangipani
 
 import (
 	"fmt"
+	"reflect"
 	"time"
 
 	"github.com/spf13/cast"
@@ -20,10 +21,18 @@ func (IPropertyResolverBuilder) NewByMap(props map[string]interface{}) PropertyR
 		newProps[k] = v
 	}
 
-	return mapBasedPropertyResolver(newProps)
+	return newMapBasedPropertyResolver(newProps)
 }
 
 // Resolves value of properties
+//
+// Placeholders in value of properties are resolved by other properties:
+//
+//   "${db.host}" - Replaced by value of "db.host"
+//   "${db.port:5432}" - Replaced by value of "db.port", or "5432" if the property is not existing
+//   "\${db.host}" - Escaped, the value is literal "${db.host}"
+//
+// See "RequiredTypedR" for errors of unresolvable placeholders.
 type PropertyResolver interface {
 	// Interface space for retrieving typed values of properties
 	Typed() TypedR
@@ -72,6 +81,9 @@ type TypedR interface {
 // Defines the getting of property for specific types
 // (with error if the property is not existing).
 //
+// The error is also viable if a placeholder could not be resolved
+// or there is circular reference between placeholders.
+//
 // These methods are cloned from "*viper.Viper"
 //
 // See "PropertyResolver.RequiredTyped()"
@@ -98,11 +110,68 @@ type RequiredTypedR interface {
 	GetByteSize(string) (bs.ByteSize, error)
 }
 
+// Converts the property to the target(must be a non-nil pointer), see "RegisterConverter()" for supported types.
+//
+// If there is no such property, the properties having the name as prefix are converted as a map(or struct),
+// e.g., "db.host" and "db.port" for "db"(the resolver must be able to list its properties, see "PropertiesLister").
+//
+// The errors contain the name of property, e.g., "Property[db.url] cannot be converted: ...".
+func GetAs(resolver PropertyResolver, name string, target interface{}) error {
+	/**
+	 * The resolvers built by this package
+	 */
+	switch resolver.(type) {
+	case mapBasedPropertyResolver, *mapBasedEnv:
+		if mapBasedResolver, ok := asMapBasedResolver(resolver); ok {
+			return requiredTypedRImpl(mapBasedResolver).getAs(name, target)
+		}
+	}
+	// :~)
+
+	/**
+	 * Other resolvers: only the nested properties are collected by the listed properties
+	 */
+	if !resolver.ContainsProperty(name) {
+		if mapBasedResolver, ok := asMapBasedResolver(resolver); ok {
+			return requiredTypedRImpl(mapBasedResolver).getAs(name, target)
+		}
+	}
+	// :~)
+
+	targetValue := reflect.ValueOf(target)
+	if targetValue.Kind() != reflect.Ptr || targetValue.IsNil() {
+		return fmt.Errorf("Target of property[%s] must be a non-nil pointer: %T", name, target)
+	}
+
+	v, err := resolver.RequiredTyped().Get(name)
+	if err != nil {
+		return err
+	}
+
+	return setConvertedValue(name, v, targetValue)
+}
+
 func init() {
 	PropertyResolverBuilder = 0
 }
 
-type mapBasedPropertyResolver map[string]interface{}
+func newMapBasedPropertyResolver(props map[string]interface{}) mapBasedPropertyResolver {
+	return mapBasedPropertyResolver {
+		props: props,
+		randoms: newRandomValues(),
+		relaxedKeys: buildRelaxedKeys(props),
+	}
+}
+
+type mapBasedPropertyResolver struct {
+	props map[string]interface{}
+	// Generated values of "random.*", which are shared by copies of this resolver
+	randoms *randomValues
+	// Relaxed names to names of properties(see "CanonicalName")
+	relaxedKeys map[string]string
+	// Looks up the properties not existing in this resolver(e.g., the base environment of overlay), could be nil
+	fallback PropertyResolver
+}
 
 func (self mapBasedPropertyResolver) Typed() TypedR {
 	return typedRImpl(self)
@@ -110,9 +179,17 @@ func (self mapBasedPropertyResolver) Typed() TypedR {
 func (self mapBasedPropertyResolver) RequiredTyped() RequiredTypedR {
 	return requiredTypedRImpl(self)
 }
+func (self mapBasedPropertyResolver) AllProperties() map[string]interface{} {
+	copiedProps := make(map[string]interface{}, len(self.props))
+	for k, v := range self.props {
+		copiedProps[k] = v
+	}
+
+	return copiedProps
+}
 func (self mapBasedPropertyResolver) ContainsProperty(name string) bool {
-	_, ok := self[name]
-	return ok
+	_, _, ok, err := self.find(name)
+	return ok && err == nil
 }
 func (self mapBasedPropertyResolver) GetProperty(name string) string {
 	return self.Typed().GetString(name)
@@ -120,10 +197,62 @@ func (self mapBasedPropertyResolver) GetProperty(name string) string {
 func (self mapBasedPropertyResolver) GetRequiredProperty(name string) (string, error) {
 	return self.RequiredTyped().GetString(name)
 }
+// Looks up the value of property by its name, relaxed name(see "CanonicalName"),
+// deprecated names(see "RegisterAlias"), or the generated value of "random.*".
+//
+// A warning is logged if the value is got by a deprecated name.
+func (self mapBasedPropertyResolver) lookup(name string) (interface{}, bool, error) {
+	value, alias, ok, err := self.find(name)
+	if ok && alias != "" {
+		warnDeprecatedAlias(alias, name)
+	}
+
+	return value, ok, err
+}
+// Finds the value of property as "lookup()" without warnings, the "alias" is the deprecated name used(if any).
+func (self mapBasedPropertyResolver) find(name string) (value interface{}, alias string, ok bool, err error) {
+	if value, ok := self.lookupByName(name); ok {
+		return value, "", true, nil
+	}
+
+	for _, alias := range aliasesOf(name) {
+		if value, ok := self.lookupByName(alias); ok {
+			return value, alias, true, nil
+		}
+	}
+
+	if self.fallback != nil && self.fallback.ContainsProperty(name) {
+		value, err = self.fallback.RequiredTyped().Get(name)
+		return value, "", true, err
+	}
+
+	value, ok, err = self.randoms.get(name)
+	return value, "", ok, err
+}
+func (self mapBasedPropertyResolver) lookupByName(name string) (interface{}, bool) {
+	if value, ok := self.props[name]; ok {
+		return value, true
+	}
+
+	return lookupRelaxed(self.props, self.relaxedKeys, name)
+}
 
 type typedRImpl mapBasedPropertyResolver
+// Gets the value with resolved placeholders.
+//
+// The original value is returned if the placeholders could not be resolved.
 func (self typedRImpl) Get(name string) interface{} {
-	return self[name]
+	v, ok, err := mapBasedPropertyResolver(self).lookup(name)
+	if !ok || err != nil {
+		return nil
+	}
+
+	resolved, err := newPlaceholderResolver(mapBasedPropertyResolver(self)).resolveProperty(name, v)
+	if err != nil {
+		return v
+	}
+
+	return resolved
 }
 func (self typedRImpl) GetBool(name string) bool {
 	return cast.ToBool(self.Get(name))
@@ -183,14 +312,25 @@ func (self typedRImpl) GetByteSize(name string) bs.ByteSize {
 }
 
 type requiredTypedRImpl mapBasedPropertyResolver
+// Gets the value with resolved placeholders.
+//
+// The error is viable if the placeholders could not be resolved.
 func (self requiredTypedRImpl) Get(name string) (interface{}, error) {
-	v, ok := self[name]
+	v, ok, err := mapBasedPropertyResolver(self).lookup(name)
+	if err != nil {
+		return nil, err
+	}
 
 	if !ok {
 		return nil, fmt.Errorf("Property[%s] is not existing", name)
 	}
 
-	return v, nil
+	resolved, err := newPlaceholderResolver(mapBasedPropertyResolver(self)).resolveProperty(name, v)
+	if err != nil {
+		return nil, fmt.Errorf("Property[%s] has unresolvable placeholder: %w", name, err)
+	}
+
+	return resolved, nil
 }
 func (self requiredTypedRImpl) GetBool(name string) (bool, error) {
 	v, err := self.Get(name)
@@ -198,7 +338,8 @@ func (self requiredTypedRImpl) GetBool(name string) (bool, error) {
 		return false, err
 	}
 
-	return cast.ToBoolE(v)
+	result, err := cast.ToBoolE(v)
+	return result, conversionError(name, err)
 }
 func (self requiredTypedRImpl) GetDuration(name string) (time.Duration, error) {
 	v, err := self.Get(name)
@@ -206,7 +347,8 @@ func (self requiredTypedRImpl) GetDuration(name string) (time.Duration, error) {
 		return time.Duration(0), err
 	}
 
-	return cast.ToDurationE(v)
+	result, err := cast.ToDurationE(v)
+	return result, conversionError(name, err)
 }
 func (self requiredTypedRImpl) GetFloat64(name string) (float64, error) {
 	v, err := self.Get(name)
@@ -214,7 +356,8 @@ func (self requiredTypedRImpl) GetFloat64(name string) (float64, error) {
 		return 0, err
 	}
 
-	return cast.ToFloat64E(v)
+	result, err := cast.ToFloat64E(v)
+	return result, conversionError(name, err)
 }
 func (self requiredTypedRImpl) GetInt(name string) (int, error) {
 	v, err := self.Get(name)
@@ -222,7 +365,8 @@ func (self requiredTypedRImpl) GetInt(name string) (int, error) {
 		return 0, err
 	}
 
-	return cast.ToIntE(v)
+	result, err := cast.ToIntE(v)
+	return result, conversionError(name, err)
 }
 func (self requiredTypedRImpl) GetInt32(name string) (int32, error) {
 	v, err := self.Get(name)
@@ -230,7 +374,8 @@ func (self requiredTypedRImpl) GetInt32(name string) (int32, error) {
 		return 0, err
 	}
 
-	return cast.ToInt32E(v)
+	result, err := cast.ToInt32E(v)
+	return result, conversionError(name, err)
 }
 func (self requiredTypedRImpl) GetInt64(name string) (int64, error) {
 	v, err := self.Get(name)
@@ -238,7 +383,8 @@ func (self requiredTypedRImpl) GetInt64(name string) (int64, error) {
 		return 0, err
 	}
 
-	return cast.ToInt64E(v)
+	result, err := cast.ToInt64E(v)
+	return result, conversionError(name, err)
 }
 func (self requiredTypedRImpl) GetIntSlice(name string) ([]int, error) {
 	v, err := self.Get(name)
@@ -246,7 +392,8 @@ func (self requiredTypedRImpl) GetIntSlice(name string) ([]int, error) {
 		return []int{}, err
 	}
 
-	return cast.ToIntSliceE(v)
+	result, err := cast.ToIntSliceE(v)
+	return result, conversionError(name, err)
 }
 func (self requiredTypedRImpl) GetString(name string) (string, error) {
 	v, err := self.Get(name)
@@ -254,7 +401,8 @@ func (self requiredTypedRImpl) GetString(name string) (string, error) {
 		return "", err
 	}
 
-	return cast.ToStringE(v)
+	result, err := cast.ToStringE(v)
+	return result, conversionError(name, err)
 }
 func (self requiredTypedRImpl) GetStringMap(name string) (map[string]interface{}, error) {
 	v, err := self.Get(name)
@@ -262,7 +410,8 @@ func (self requiredTypedRImpl) GetStringMap(name string) (map[string]interface{}
 		return map[string]interface{}{}, err
 	}
 
-	return cast.ToStringMapE(v)
+	result, err := cast.ToStringMapE(v)
+	return result, conversionError(name, err)
 }
 func (self requiredTypedRImpl) GetStringMapString(name string) (map[string]string, error) {
 	v, err := self.Get(name)
@@ -270,7 +419,8 @@ func (self requiredTypedRImpl) GetStringMapString(name string) (map[string]strin
 		return map[string]string{}, err
 	}
 
-	return cast.ToStringMapStringE(v)
+	result, err := cast.ToStringMapStringE(v)
+	return result, conversionError(name, err)
 }
 func (self requiredTypedRImpl) GetStringMapStringSlice(name string) (map[string][]string, error) {
 	v, err := self.Get(name)
@@ -278,7 +428,8 @@ func (self requiredTypedRImpl) GetStringMapStringSlice(name string) (map[string]
 		return map[string][]string{}, err
 	}
 
-	return cast.ToStringMapStringSliceE(v)
+	result, err := cast.ToStringMapStringSliceE(v)
+	return result, conversionError(name, err)
 }
 func (self requiredTypedRImpl) GetStringSlice(name string) ([]string, error) {
 	v, err := self.Get(name)
@@ -286,7 +437,8 @@ func (self requiredTypedRImpl) GetStringSlice(name string) ([]string, error) {
 		return []string{}, err
 	}
 
-	return cast.ToStringSliceE(v)
+	result, err := cast.ToStringSliceE(v)
+	return result, conversionError(name, err)
 }
 func (self requiredTypedRImpl) GetTime(name string) (time.Time, error) {
 	v, err := self.Get(name)
@@ -294,7 +446,8 @@ func (self requiredTypedRImpl) GetTime(name string) (time.Time, error) {
 		return time.Unix(0, 0), err
 	}
 
-	return cast.ToTimeE(v)
+	result, err := cast.ToTimeE(v)
+	return result, conversionError(name, err)
 }
 func (self requiredTypedRImpl) GetUint(name string) (uint, error) {
 	v, err := self.Get(name)
@@ -302,7 +455,8 @@ func (self requiredTypedRImpl) GetUint(name string) (uint, error) {
 		return 0, err
 	}
 
-	return cast.ToUintE(v)
+	result, err := cast.ToUintE(v)
+	return result, conversionError(name, err)
 }
 func (self requiredTypedRImpl) GetUint32(name string) (uint32, error) {
 	v, err := self.Get(name)
@@ -310,7 +464,8 @@ func (self requiredTypedRImpl) GetUint32(name string) (uint32, error) {
 		return 0, err
 	}
 
-	return cast.ToUint32E(v)
+	result, err := cast.ToUint32E(v)
+	return result, conversionError(name, err)
 }
 func (self requiredTypedRImpl) GetUint64(name string) (uint64, error) {
 	v, err := self.Get(name)
@@ -318,7 +473,8 @@ func (self requiredTypedRImpl) GetUint64(name string) (uint64, error) {
 		return 0, err
 	}
 
-	return cast.ToUint64E(v)
+	result, err := cast.ToUint64E(v)
+	return result, conversionError(name, err)
 }
 func (self requiredTypedRImpl) GetByteSize(name string) (bs.ByteSize, error) {
 	v, err := self.GetString(name)
@@ -326,5 +482,51 @@ func (self requiredTypedRImpl) GetByteSize(name string) (bs.ByteSize, error) {
 		return 0, err
 	}
 
-	return bs.Parse(v)
+	result, err := bs.Parse(v)
+	return result, conversionError(name, err)
+}
+func (self requiredTypedRImpl) getAs(name string, target interface{}) error {
+	targetValue := reflect.ValueOf(target)
+	if targetValue.Kind() != reflect.Ptr || targetValue.IsNil() {
+		return fmt.Errorf("Target of property[%s] must be a non-nil pointer: %T", name, target)
+	}
+
+	/**
+	 * The properties having the name as prefix are collected as a map(e.g., "db.host" and "db.port" for "db")
+	 */
+	var v interface{}
+	var err error
+	if nestedProps, found := collectByPrefix(self.props, name); found && !mapBasedPropertyResolver(self).ContainsProperty(name) {
+		resolvedProps, err := newPlaceholderResolver(mapBasedPropertyResolver(self)).resolveValue(nestedProps)
+		if err != nil {
+			return fmt.Errorf("Property[%s] has unresolvable placeholder: %w", name, err)
+		}
+		v = resolvedProps
+	} else {
+		v, err = self.Get(name)
+		if err != nil {
+			return err
+		}
+	}
+	// :~)
+
+	return setConvertedValue(name, v, targetValue)
+}
+func setConvertedValue(name string, value interface{}, targetValue reflect.Value) error {
+	convertedValue, err := convertValue(value, targetValue.Type().Elem())
+	if err != nil {
+		return conversionError(name, err)
+	}
+
+	targetValue.Elem().Set(convertedValue)
+	return nil
+}
+
+// Adds name of property to the error of conversion(nil if there is no error)
+func conversionError(name string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return fmt.Errorf("Property[%s] cannot be converted: %w", name, err)
 }
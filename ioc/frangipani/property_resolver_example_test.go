@@ -2,6 +2,9 @@ package frangipani
 
 import (
 	"fmt"
+	"net"
+	"net/url"
+	"time"
 )
 
 func ExamplePropertyResolver_containsProperty() {
@@ -41,6 +44,20 @@ func ExamplePropertyResolver_getRequiredProperty() {
 	// err: Property[a2] is not existing
 }
 
+func ExamplePropertyResolver_placeholder() {
+	testedResolver := PropertyResolverBuilder.NewByMap(map[string]interface{} {
+		"db.host": "10.7.81.33",
+		"db.url": "pg://${db.host}:${db.port:5432}",
+		"db.comment": `\${db.host} is literal`,
+	})
+
+	fmt.Printf("db.url: %v\n", testedResolver.GetProperty("db.url"))
+	fmt.Printf("db.comment: %v\n", testedResolver.GetProperty("db.comment"))
+	// Output:
+	// db.url: pg://10.7.81.33:5432
+	// db.comment: ${db.host} is literal
+}
+
 func ExampleTypedR_getIntFamily() {
 	typedR := PropertyResolverBuilder.NewByMap(map[string]interface{} {
 		"v1": int8(20), "v2": 40,
@@ -96,7 +113,7 @@ func ExampleRequiredTypedR_getByteSize() {
 
 	// Output:
 	// Limit: 10240KB
-	// Format error: Unrecognized size suffix cm
+	// Format error: Property[size.wrong-format] cannot be converted: Unrecognized size suffix cm
 }
 
 func ExampleRequiredTypedR_notExistingProperty() {
@@ -129,5 +146,44 @@ func ExampleRequiredTypedR_getSliceFamily() {
 	// Output:
 	// int slice: [90 91 92]
 	// string slice: [GP-1 GP-2 GP-3]
-	// wrong type: unable to cast map[int]int{} of type map[int]int to []string
+	// wrong type: Property[s-wrong] cannot be converted: unable to cast map[int]int{} of type map[int]int to []string
+}
+
+func ExampleGetAs() {
+	resolver := PropertyResolverBuilder.NewByMap(map[string]interface{} {
+		"db.url": "pg://10.7.81.33:5432/sugar-cane",
+		"db.timeouts": []interface{}{ "1s", "5s" },
+		"db.allowed": "10.7.0.0/16",
+	})
+
+	var dbUrl *url.URL
+	var timeouts []time.Duration
+	var allowed *net.IPNet
+	GetAs(resolver, "db.url", &dbUrl)
+	GetAs(resolver, "db.timeouts", &timeouts)
+	GetAs(resolver, "db.allowed", &allowed)
+
+	fmt.Printf("Host: %s. Timeouts: %v. Allowed: %v\n", dbUrl.Host, timeouts, allowed.Contains(net.ParseIP("10.7.1.1")))
+
+	var ip net.IP
+	err := GetAs(resolver, "db.url", &ip)
+	fmt.Printf("Error: %v", err)
+	// Output:
+	// Host: 10.7.81.33:5432. Timeouts: [1s 5s]. Allowed: true
+	// Error: Property[db.url] cannot be converted: invalid IP address: pg://10.7.81.33:5432/sugar-cane
+}
+
+func ExampleGet() {
+	env := EnvBuilder.NewByMap(map[string]interface{} {
+		"server.port": 8080,
+		"server.hosts": []interface{}{ "10.1.1.1", "10.1.1.2" },
+	})
+
+	port, _ := Get[int](env, "server.port")
+	hosts := MustGet[[]net.IP](env, "server.hosts")
+	timeout := GetOr(env, "server.timeout", 5 * time.Second)
+
+	fmt.Printf("Port: %d. Hosts: %v. Timeout: %v", port, hosts, timeout)
+	// Output:
+	// Port: 8080. Hosts: [10.1.1.1 10.1.1.2]. Timeout: 5s
 }
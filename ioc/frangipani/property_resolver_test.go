@@ -14,7 +14,7 @@ var _ = Describe("Property resolver", func() {
 	}
 
 	Context("propertyResolverMapImpl", func() {
-		testedImpl := mapBasedPropertyResolver(sampleProps)
+		testedImpl := newMapBasedPropertyResolver(sampleProps)
 
 		DescribeTable("ContainsProperty",
 			func(name string, expected bool) {
@@ -54,7 +54,7 @@ var _ = Describe("Property resolver", func() {
 	})
 
 	Context("requiredTypedRImpl", func() {
-		testedImpl := requiredTypedRImpl(sampleProps)
+		testedImpl := requiredTypedRImpl(newMapBasedPropertyResolver(sampleProps))
 
 		It("Get value", func() {
 			v, err := testedImpl.GetUint64("v1")
@@ -85,12 +85,45 @@ var _ = Describe("Property resolver", func() {
 		It("Conversion error", func() {
 			_, err := testedImpl.GetUint32("v2")
 
-			Expect(err).To(MatchError(MatchRegexp(`unable to cast`)))
+			Expect(err).To(MatchError(MatchRegexp(`Property\[v2\] cannot be converted: unable to cast`)))
+		})
+
+	})
+
+	Context("GetAs", func() {
+		testedResolver := PropertyResolverBuilder.NewByMap(sampleProps)
+
+		It("Converted value", func() {
+			var v uint16
+			err := GetAs(testedResolver, "v1", &v)
+
+			Expect(err).To(Succeed())
+			Expect(v).To(BeEquivalentTo(20))
+		})
+
+		DescribeTable("Errors",
+			func(name string, target interface{}, expectedErr string) {
+				err := GetAs(testedResolver, name, target)
+				Expect(err).To(MatchError(MatchRegexp(expectedErr)))
+			},
+			Entry("Not existing", "v3", new(int), `Property\[v3\] is not existing`),
+			Entry("Conversion error", "v2", new(int), `Property\[v2\] cannot be converted`),
+			Entry("Not a pointer", "v1", 0, `must be a non-nil pointer`),
+		)
+
+		It("Resolver implemented outside of this package", func() {
+			externalResolver := &sampleExternalResolver{ testedResolver }
+
+			var v uint16
+			Expect(GetAs(externalResolver, "v1", &v)).To(Succeed())
+			Expect(v).To(BeEquivalentTo(20))
+
+			Expect(GetAs(externalResolver, "v3", &v)).To(MatchError(MatchRegexp(`Property\[v3\] is not existing`)))
 		})
 	})
 
 	Context("typedRImpl", func() {
-		testedImpl := typedRImpl(sampleProps)
+		testedImpl := typedRImpl(newMapBasedPropertyResolver(sampleProps))
 
 		It("Get value", func() {
 			v := testedImpl.GetUint64("v1")
@@ -118,3 +151,8 @@ var _ = Describe("Property resolver", func() {
 		})
 	})
 })
+
+// The resolver which cannot list its properties(not implementing "PropertiesLister")
+type sampleExternalResolver struct {
+	PropertyResolver
+}
@@ -0,0 +1,228 @@
+package frangipani
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"math/big"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// The prefix of properties having generated values: "random."
+//
+// The supported names are:
+//
+//   "random.int" - A non-negative integer(32 bits)
+//   "random.int(100)" - An integer in [0, 100)
+//   "random.int(1,100)" - An integer in [1, 100)
+//   "random.long", "random.long(100)", "random.long(1,100)" - Same as "random.int", but in 64 bits
+//   "random.uuid" - An UUID(version 4), e.g., "0f8fad5b-d9cb-469f-a165-70867728950e"
+//   "random.port" - A free TCP port of local host
+//   "random.string", "random.string(16)" - Alphanumeric text(default length is 16)
+//   "random.value" - 32 hexadecimal characters
+//
+// The values are generated once per environment, the reading of same name gives the same value.
+//
+// The generated values are only used when there is no property of the same name.
+const RANDOM_PREFIX = "random."
+
+const defaultRandomStringLength = 16
+
+const alphanumericCharacters = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+func newRandomValues() *randomValues {
+	return &randomValues {
+		values: make(map[string]interface{}),
+	}
+}
+
+// Keeps generated values of "random.*" properties.
+type randomValues struct {
+	lock sync.Mutex
+	values map[string]interface{}
+}
+// Gets(or generates) the value for the name of property.
+//
+// The second returned value is false if the name is not leaded by "random.".
+func (self *randomValues) get(name string) (interface{}, bool, error) {
+	if self == nil || !strings.HasPrefix(name, RANDOM_PREFIX) {
+		return nil, false, nil
+	}
+
+	key := strings.ReplaceAll(name, " ", "")
+
+	self.lock.Lock()
+	defer self.lock.Unlock()
+
+	if value, ok := self.values[key]; ok {
+		return value, true, nil
+	}
+
+	value, err := generateRandomValue(key[len(RANDOM_PREFIX):])
+	if err != nil {
+		return nil, true, fmt.Errorf("Random property[%s] cannot be generated: %w", name, err)
+	}
+
+	self.values[key] = value
+	return value, true, nil
+}
+
+// Generates value by the expression(without "random."), e.g., "int(1,100)".
+func generateRandomValue(expression string) (interface{}, error) {
+	kind, args, err := parseRandomExpression(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	switch kind {
+	case "int":
+		return randomInRange(args, math.MaxInt32, func(v int64) interface{} { return int(v) })
+	case "long":
+		return randomInRange(args, math.MaxInt64, func(v int64) interface{} { return v })
+	case "uuid":
+		if len(args) > 0 {
+			return nil, fmt.Errorf("\"uuid\" has no argument")
+		}
+		return randomUuid()
+	case "port":
+		if len(args) > 0 {
+			return nil, fmt.Errorf("\"port\" has no argument")
+		}
+		return freePort()
+	case "string":
+		length := int64(defaultRandomStringLength)
+		switch len(args) {
+		case 0:
+		case 1:
+			length = args[0]
+		default:
+			return nil, fmt.Errorf("\"string\" has at most one argument")
+		}
+		if length <= 0 {
+			return nil, fmt.Errorf("Length of \"string\" must be positive: %d", length)
+		}
+		return randomString(int(length))
+	case "value":
+		if len(args) > 0 {
+			return nil, fmt.Errorf("\"value\" has no argument")
+		}
+		bytes, err := randomBytes(16)
+		if err != nil {
+			return nil, err
+		}
+		return hex.EncodeToString(bytes), nil
+	}
+
+	return nil, fmt.Errorf("Unknown type of random value: %q", kind)
+}
+
+// Parses "int(1,100)" to "int" and [1, 100]
+func parseRandomExpression(expression string) (string, []int64, error) {
+	openIndex := strings.Index(expression, "(")
+	if openIndex == -1 {
+		return expression, []int64{}, nil
+	}
+
+	if !strings.HasSuffix(expression, ")") {
+		return "", nil, fmt.Errorf("Missing \")\" in %q", expression)
+	}
+
+	argsText := expression[openIndex + 1:len(expression) - 1]
+	if argsText == "" {
+		return expression[:openIndex], []int64{}, nil
+	}
+
+	args := make([]int64, 0, 2)
+	for _, argText := range strings.Split(argsText, ",") {
+		arg, err := strconv.ParseInt(argText, 10, 64)
+		if err != nil {
+			return "", nil, fmt.Errorf("Argument of %q is not an integer: %w", expression, err)
+		}
+		args = append(args, arg)
+	}
+
+	return expression[:openIndex], args, nil
+}
+
+// The "args" could be empty, "(max)", or "(min,max)"
+func randomInRange(args []int64, limit int64, toValue func(int64) interface{}) (interface{}, error) {
+	min, max := int64(0), limit
+	switch len(args) {
+	case 0:
+	case 1:
+		max = args[0]
+	case 2:
+		min, max = args[0], args[1]
+	default:
+		return nil, fmt.Errorf("Too many arguments for range: %v", args)
+	}
+
+	if min >= max {
+		return nil, fmt.Errorf("The range is empty: [%d, %d)", min, max)
+	}
+	if max > limit || min < -limit {
+		return nil, fmt.Errorf("The range is out of bound: [%d, %d)", min, max)
+	}
+
+	/**
+	 * The size of range may exceed int64, e.g., (-limit, limit)
+	 */
+	rangeSize := new(big.Int).Sub(big.NewInt(max), big.NewInt(min))
+	offset, err := rand.Int(rand.Reader, rangeSize)
+	if err != nil {
+		return nil, err
+	}
+	// :~)
+
+	return toValue(offset.Add(offset, big.NewInt(min)).Int64()), nil
+}
+
+func randomUuid() (string, error) {
+	bytes, err := randomBytes(16)
+	if err != nil {
+		return "", err
+	}
+
+	bytes[6] = (bytes[6] & 0x0f) | 0x40 // Version 4
+	bytes[8] = (bytes[8] & 0x3f) | 0x80 // Variant RFC 4122
+
+	text := hex.EncodeToString(bytes)
+	return fmt.Sprintf("%s-%s-%s-%s-%s", text[0:8], text[8:12], text[12:16], text[16:20], text[20:]), nil
+}
+
+// The port is released after it is found, it is available unless being taken by other processes.
+func freePort() (int, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer listener.Close()
+
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
+
+func randomString(length int) (string, error) {
+	bytes, err := randomBytes(length)
+	if err != nil {
+		return "", err
+	}
+
+	for i, b := range bytes {
+		bytes[i] = alphanumericCharacters[int(b) % len(alphanumericCharacters)]
+	}
+
+	return string(bytes), nil
+}
+
+func randomBytes(size int) ([]byte, error) {
+	bytes := make([]byte, size)
+	if _, err := rand.Read(bytes); err != nil {
+		return nil, err
+	}
+
+	return bytes, nil
+}
@@ -0,0 +1,90 @@
+package frangipani
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Random values", func() {
+	DescribeTable("generateRandomValue",
+		func(expression string, matcher OmegaMatcher) {
+			testedValue, err := generateRandomValue(expression)
+
+			Expect(err).To(Succeed())
+			Expect(testedValue).To(matcher)
+		},
+		Entry("int", "int", And(BeNumerically(">=", 0), BeAssignableToTypeOf(0))),
+		Entry("int(max)", "int(3)", BeNumerically("<", 3)),
+		Entry("int(min,max)", "int(-5,-3)", And(BeNumerically(">=", -5), BeNumerically("<", -3))),
+		Entry("long(min,max)", "long(10000000000,10000000002)", And(
+			BeNumerically(">=", int64(10000000000)), BeAssignableToTypeOf(int64(0)),
+		)),
+		Entry("uuid", "uuid", MatchRegexp(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)),
+		Entry("port", "port", And(BeNumerically(">", 0), BeNumerically("<", 65536))),
+		Entry("string", "string", MatchRegexp(`^[0-9a-zA-Z]{16}$`)),
+		Entry("string(length)", "string(5)", MatchRegexp(`^[0-9a-zA-Z]{5}$`)),
+		Entry("value", "value", MatchRegexp(`^[0-9a-f]{32}$`)),
+	)
+
+	DescribeTable("generateRandomValue(error)",
+		func(expression string, expectedErr string) {
+			_, err := generateRandomValue(expression)
+			Expect(err).To(MatchError(MatchRegexp(expectedErr)))
+		},
+		Entry("Unknown type", "double", `Unknown type`),
+		Entry("Empty range", "int(10,10)", `range is empty`),
+		Entry("Out of bound", "int(3000000000)", `out of bound`),
+		Entry("Not integer", "string(a)", `not an integer`),
+		Entry("Missing parenthesis", "int(1,2", `Missing`),
+	)
+
+	Context("Environment", func() {
+		It("Stable values", func() {
+			testedEnv := EnvBuilder.NewByMap(map[string]interface{} {
+				"db.name": "test-${random.string(8)}",
+				"db.url": "pg://localhost:${random.port}/${random.string(8)}",
+			})
+
+			name := testedEnv.GetProperty("db.name")
+			port := testedEnv.Typed().GetInt("random.port")
+
+			Expect(name).To(MatchRegexp(`^test-[0-9a-zA-Z]{8}$`))
+			Expect(testedEnv.GetProperty("db.name")).To(Equal(name))
+			Expect(testedEnv.GetProperty("db.url")).To(Equal(
+				fmt.Sprintf("pg://localhost:%d/%s", port, name[len("test-"):]),
+			))
+			Expect(testedEnv.ContainsProperty("random.uuid")).To(BeTrue())
+			Expect(testedEnv.GetProperty("random.uuid")).To(Equal(testedEnv.GetProperty("random.uuid")))
+		})
+
+		It("Overridden by property", func() {
+			testedEnv := EnvBuilder.NewByMap(map[string]interface{} {
+				"random.port": 8080,
+			})
+
+			Expect(testedEnv.Typed().GetInt("random.port")).To(Equal(8080))
+		})
+
+		It("Binding", func() {
+			testedEnv := EnvBuilder.NewByMap(map[string]interface{} {
+				"server.port": "${random.int(1000,2000)}",
+			})
+
+			var config struct {
+				Port int `fg:"port"`
+			}
+			Expect(BindProperties(testedEnv, "server", &config)).To(Succeed())
+			Expect(config.Port).To(Equal(testedEnv.Typed().GetInt("server.port")))
+		})
+
+		It("Invalid expression", func() {
+			testedEnv := EnvBuilder.NewByMap(map[string]interface{} {})
+
+			Expect(testedEnv.ContainsProperty("random.int(a)")).To(BeFalse())
+			_, err := testedEnv.GetRequiredProperty("random.int(a)")
+			Expect(err).To(MatchError(MatchRegexp(`cannot be generated`)))
+		})
+	})
+})
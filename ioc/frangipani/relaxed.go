@@ -0,0 +1,172 @@
+package frangipani
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/spf13/cast"
+)
+
+// Converts the name of property to canonical form, which is lower-case, separated by dots, and kebab-case in segments.
+//
+//   "server.httpPort" - "server.http-port"
+//   "server.http_port" - "server.http-port"
+//   "servers.0.host" - "servers[0].host"
+//   "Servers[0].Host" - "servers[0].host"
+//
+// The names having the same canonical form(ignoring dashes) are treated as the same property by "Environment",
+// e.g., "server.http-port", "server.httpPort", and "server.httpport"(viper keeps keys as lower case).
+func CanonicalName(name string) string {
+	var result strings.Builder
+
+	segments := strings.Split(
+		strings.ReplaceAll(strings.ReplaceAll(name, "[", "."), "]", ""),
+		".",
+	)
+	for _, segment := range segments {
+		if segment == "" {
+			continue
+		}
+
+		if _, err := strconv.Atoi(segment); err == nil {
+			result.WriteString("[" + segment + "]")
+			continue
+		}
+
+		if result.Len() > 0 {
+			result.WriteString(".")
+		}
+		result.WriteString(kebabCase(segment))
+	}
+
+	return result.String()
+}
+
+// "httpPort" and "http_port" are converted to "http-port"
+func kebabCase(segment string) string {
+	var result strings.Builder
+	var previous rune
+
+	for _, r := range segment {
+		switch {
+		case r == '_' || r == '-':
+			if previous != '-' && previous != 0 {
+				result.WriteRune('-')
+			}
+			r = '-'
+		case unicode.IsUpper(r):
+			if unicode.IsLower(previous) || unicode.IsDigit(previous) {
+				result.WriteRune('-')
+			}
+			result.WriteRune(unicode.ToLower(r))
+		default:
+			result.WriteRune(r)
+		}
+
+		previous = r
+	}
+
+	return strings.TrimSuffix(result.String(), "-")
+}
+
+// The canonical form without dashes, and the indexes are separated by dots, e.g., "servers.0.httpport".
+func relaxedName(name string) string {
+	relaxed := strings.ReplaceAll(CanonicalName(name), "-", "")
+	relaxed = strings.ReplaceAll(relaxed, "[", ".")
+	return strings.ReplaceAll(relaxed, "]", "")
+}
+
+// Builds the map of relaxed names to the names of properties.
+//
+// If there are multiple names having the same relaxed name, the canonical one(or the first one by order) is used.
+func buildRelaxedKeys(props map[string]interface{}) map[string]string {
+	names := make([]string, 0, len(props))
+	for name := range props {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	relaxedKeys := make(map[string]string, len(props))
+	for _, name := range names {
+		relaxed := relaxedName(name)
+
+		existingName, ok := relaxedKeys[relaxed]
+		if !ok || (existingName != CanonicalName(existingName) && name == CanonicalName(name)) {
+			relaxedKeys[relaxed] = name
+		}
+	}
+
+	return relaxedKeys
+}
+
+// Looks up the property by its relaxed name.
+//
+// If there is no such property, the elements of slices(or maps) are looked up,
+// e.g., "servers[0].host" is looked up from the value of "servers".
+func lookupRelaxed(props map[string]interface{}, relaxedKeys map[string]string, name string) (interface{}, bool) {
+	relaxed := relaxedName(name)
+	if key, ok := relaxedKeys[relaxed]; ok {
+		return props[key], true
+	}
+
+	segments := strings.Split(relaxed, ".")
+	for i := len(segments) - 1; i > 0; i-- {
+		key, ok := relaxedKeys[strings.Join(segments[:i], ".")]
+		if !ok {
+			continue
+		}
+
+		return lookupElement(props[key], segments[i:])
+	}
+
+	return nil, false
+}
+
+// The segments are relaxed names of indexes or keys of maps
+func lookupElement(value interface{}, segments []string) (interface{}, bool) {
+	if len(segments) == 0 {
+		return value, true
+	}
+
+	switch v := value.(type) {
+	case []interface{}:
+		index, err := strconv.Atoi(segments[0])
+		if err != nil || index < 0 || index >= len(v) {
+			return nil, false
+		}
+
+		return lookupElement(v[index], segments[1:])
+	case []string:
+		index, err := strconv.Atoi(segments[0])
+		if err != nil || index < 0 || index >= len(v) || len(segments) > 1 {
+			return nil, false
+		}
+
+		return v[index], true
+	}
+
+	valueMap, err := cast.ToStringMapE(value)
+	if err != nil {
+		return nil, false
+	}
+
+	/**
+	 * The key of map may contain dots, e.g., "a.b" in { "a.b": 1 }
+	 */
+	for key, elem := range valueMap {
+		keySegments := strings.Split(relaxedName(key), ".")
+		if len(keySegments) > len(segments) ||
+			strings.Join(keySegments, ".") != strings.Join(segments[:len(keySegments)], ".") {
+			continue
+		}
+
+		if found, ok := lookupElement(elem, segments[len(keySegments):]); ok {
+			return found, true
+		}
+	}
+	// :~)
+
+	return nil, false
+}
@@ -0,0 +1,64 @@
+package frangipani
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Relaxed names", func() {
+	DescribeTable("CanonicalName",
+		func(name string, expected string) {
+			Expect(CanonicalName(name)).To(Equal(expected))
+		},
+		Entry("Canonical", "server.http-port", "server.http-port"),
+		Entry("Camel case", "server.httpPort", "server.http-port"),
+		Entry("Underscore", "server.http_port", "server.http-port"),
+		Entry("Upper case", "SERVER.HTTP", "server.http"),
+		Entry("Index", "servers.0.host", "servers[0].host"),
+		Entry("Index(brackets)", "Servers[0].Host", "servers[0].host"),
+	)
+
+	Context("Environment", func() {
+		testedEnv := EnvBuilder.NewByMap(map[string]interface{} {
+			"server.httpport": 8080,
+			"server.max-conn": 20,
+			"server.url": "http://localhost:${server.http-port}",
+			"servers": []interface{} {
+				map[string]interface{} { "host": "10.0.0.1", "httpPort": 80 },
+			},
+			"db": map[string]interface{} { "host": "10.0.0.5" },
+		})
+
+		DescribeTable("Lookup by relaxed names",
+			func(name string, expected string) {
+				Expect(testedEnv.ContainsProperty(name)).To(BeTrue())
+				Expect(testedEnv.GetProperty(name)).To(Equal(expected))
+			},
+			Entry("Kebab case", "server.http-port", "8080"),
+			Entry("Camel case", "server.maxConn", "20"),
+			Entry("Placeholder", "server.url", "http://localhost:8080"),
+			Entry("Element of slice", "servers[0].host", "10.0.0.1"),
+			Entry("Field of element(relaxed)", "servers[0].http-port", "80"),
+			Entry("Value of map", "db.host", "10.0.0.5"),
+		)
+
+		DescribeTable("Not existing",
+			func(name string) {
+				Expect(testedEnv.ContainsProperty(name)).To(BeFalse())
+			},
+			Entry("Index out of range", "servers[1].host"),
+			Entry("Not a map", "server.max-conn.value"),
+		)
+
+		It("Binding", func() {
+			var config struct {
+				HttpPort int `fg:"http-port"`
+				MaxConn int
+			}
+
+			Expect(BindProperties(testedEnv, "server", &config)).To(Succeed())
+			Expect(config.HttpPort).To(Equal(8080))
+			Expect(config.MaxConn).To(Equal(20))
+		})
+	})
+})